@@ -0,0 +1,284 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+const manifestHelp = `The manifest lists the desired servers per scope and plugin states:
+
+  servers:
+    user:
+      context7:
+        command: npx
+        args: ["-y", "@upstash/context7-mcp"]
+    project:
+      docs:
+        type: http
+        url: https://docs.example.com/mcp
+  plugins:
+    serena@claude-plugins: true
+
+Servers are compared field by field; fields the manifest does not mention
+(such as "timeout", or an env var or header it leaves out) are left
+untouched. With --prune, servers missing from a declared scope are removed,
+enabled plugins missing from a declared plugins section are disabled, and
+args, env vars and headers a listed server has but the manifest omits are
+removed; fields this tool does not model, like "timeout", are still kept.
+Scopes the manifest omits are never changed.`
+
+func newPlanCmd() *cobra.Command {
+	var file string
+	var prune bool
+
+	cmd := &cobra.Command{
+		Use:   "plan -f <manifest>",
+		Short: "Show changes needed to match a manifest",
+		Long: `Compare a declarative manifest (YAML or JSON) with the current Claude Code
+configuration and show what 'apply' would change, without writing anything.
+
+` + manifestHelp + `
+
+Examples:
+  mcp-plugin plan -f servers.yaml
+  mcp-plugin plan -f servers.yaml --prune --output json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			changes, err := planManifest(file, prune)
+			if err != nil {
+				return err
+			}
+			if err := checkChangesPolicy(changes); err != nil {
+				warnf("apply would be refused: %v", err)
+			}
+			if handled, err := render("Plan", newPlanView(changes), func() table {
+				return planTable(changes)
+			}); handled {
+				return err
+			}
+			printPlan(changes)
+			return nil
+		},
+	}
+
+	addManifestFlags(cmd, &file, &prune)
+	return cmd
+}
+
+func newApplyCmd() *cobra.Command {
+	var file string
+	var prune bool
+
+	cmd := &cobra.Command{
+		Use:   "apply -f <manifest>",
+		Short: "Converge the configuration to a manifest",
+		Long: `Apply a declarative manifest (YAML or JSON): add missing servers, update
+changed ones and set plugin states. Every write is backed up, so
+'config rollback' can undo individual steps.
+
+` + manifestHelp + `
+
+Examples:
+  # Preview, then apply
+  mcp-plugin plan -f servers.yaml
+  mcp-plugin apply -f servers.yaml
+
+  # Also remove servers the manifest does not list
+  mcp-plugin apply -f servers.yaml --prune`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runApply(file, prune)
+		},
+	}
+
+	addManifestFlags(cmd, &file, &prune)
+	return cmd
+}
+
+func addManifestFlags(cmd *cobra.Command, file *string, prune *bool) {
+	cmd.Flags().StringVarP(file, "file", "f", "", "Manifest file (YAML or JSON)")
+	cmd.Flags().BoolVar(prune, "prune", false, "Remove servers and disable plugins not listed in the manifest")
+	_ = cmd.MarkFlagRequired("file")
+}
+
+func planManifest(file string, prune bool) ([]config.Change, error) {
+	manifest, err := config.LoadManifest(file)
+	if err != nil {
+		return nil, err
+	}
+	for scope, servers := range manifest.Servers {
+		for name, entry := range servers {
+			prepared, err := prepareSecrets(entry)
+			if err != nil {
+				return nil, fmt.Errorf("server '%s' in %s scope: %w", name, scope, err)
+			}
+			servers[name] = prepared
+		}
+	}
+	changes, err := newWriter().Plan(manifest, prune)
+	if err != nil {
+		return nil, fmt.Errorf("failed to plan changes: %w", err)
+	}
+	return changes, nil
+}
+
+func runApply(file string, prune bool) error {
+	changes, err := planManifest(file, prune)
+	if err != nil {
+		return err
+	}
+
+	printPlan(changes)
+	if len(changes) == 0 {
+		return nil
+	}
+	if err := checkChangesPolicy(changes); err != nil {
+		return fmt.Errorf("nothing applied: %w", err)
+	}
+
+	applied, err := newWriter().Apply(changes)
+	if err != nil {
+		fmt.Printf("\n⚠️  Applied %d of %d change(s)\n", applied, len(changes))
+		return err
+	}
+
+	fmt.Printf("\n✅ Applied %d change(s)\n", applied)
+	fmt.Println("\nNote: Restart Claude Code for changes to take effect.")
+	return nil
+}
+
+// checkChangesPolicy applies the policies to the servers a plan creates or
+// updates, so apply writes all of the manifest or none of it.
+func checkChangesPolicy(changes []config.Change) error {
+	policies, err := loadPolicies()
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, c := range changes {
+		if c.Kind != config.KindServer || c.Action == config.ActionDelete {
+			continue
+		}
+		if err := checkPolicy(policies, c.Name, c.Scope, c.Entry()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// planView is the structured form of `plan`.
+type planView struct {
+	Create  int             `json:"create"`
+	Update  int             `json:"update"`
+	Delete  int             `json:"delete"`
+	Changes []config.Change `json:"changes"`
+}
+
+func newPlanView(changes []config.Change) planView {
+	view := planView{Changes: make([]config.Change, 0, len(changes))}
+	for _, c := range changes {
+		switch c.Action {
+		case config.ActionCreate:
+			view.Create++
+		case config.ActionUpdate:
+			view.Update++
+		case config.ActionDelete:
+			view.Delete++
+		}
+		c.Fields = maskFieldChanges(c.Fields)
+		view.Changes = append(view.Changes, c)
+	}
+	return view
+}
+
+func planTable(changes []config.Change) table {
+	t := table{header: []string{"ACTION", "KIND", "SCOPE", "NAME", "FIELDS"}}
+	for _, c := range changes {
+		fields := make([]string, 0, len(c.Fields))
+		for _, f := range c.Fields {
+			fields = append(fields, f.Field)
+		}
+		t.rows = append(t.rows, []string{
+			string(c.Action), c.Kind, orDash(string(c.Scope)), c.Name, orDash(strings.Join(fields, ",")),
+		})
+	}
+	return t
+}
+
+// actionSymbols are the terraform-style markers for plan output.
+var actionSymbols = map[config.Action]string{
+	config.ActionCreate: "+",
+	config.ActionUpdate: "~",
+	config.ActionDelete: "-",
+}
+
+func printPlan(changes []config.Change) {
+	if len(changes) == 0 {
+		fmt.Println("No changes. Configuration matches the manifest.")
+		return
+	}
+
+	view := newPlanView(changes)
+	for _, c := range view.Changes {
+		target := c.Name
+		if c.Scope != "" {
+			target = string(c.Scope) + "/" + c.Name
+		}
+		fmt.Printf("  %s %s %s\n", actionSymbols[c.Action], c.Kind, target)
+		if c.Action == config.ActionDelete {
+			continue
+		}
+		for _, f := range c.Fields {
+			printFieldChange(f, "      ")
+		}
+	}
+
+	fmt.Printf("\nPlan: %d to create, %d to update, %d to delete.\n", view.Create, view.Update, view.Delete)
+}
+
+func printFieldChange(f config.FieldChange, indent string) {
+	switch {
+	case f.Old == "":
+		fmt.Printf("%s+ %s: %s\n", indent, f.Field, f.New)
+	case f.New == "":
+		fmt.Printf("%s- %s: %s\n", indent, f.Field, f.Old)
+	default:
+		fmt.Printf("%s~ %s: %s → %s\n", indent, f.Field, f.Old, f.New)
+	}
+}
+
+// maskFieldChanges masks header and env values that may hold credentials.
+func maskFieldChanges(fields []config.FieldChange) []config.FieldChange {
+	masked := make([]config.FieldChange, 0, len(fields))
+	for _, f := range fields {
+		root, key, _ := strings.Cut(f.Field, ".")
+		var mask func(string) string
+		switch root {
+		case "headers":
+			mask = func(v string) string { return maskSensitiveHeader(key, v) }
+		case "env":
+			mask = maskSecret
+		}
+		if mask != nil {
+			if f.Old != "" {
+				f.Old = mask(f.Old)
+			}
+			if f.New != "" {
+				f.New = mask(f.New)
+			}
+			// Masking can make different values look equal; say so.
+			if f.Old != "" && f.New != "" && f.Old == f.New {
+				f.New += " (changed)"
+			}
+		}
+		masked = append(masked, f)
+	}
+	return masked
+}
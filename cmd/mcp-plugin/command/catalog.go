@@ -0,0 +1,457 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package command
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/catalog"
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/config"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+func newCatalogCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "catalog",
+		Short: "Browse the catalog of known MCP servers",
+		Long: `The catalog lists well-known MCP servers with their package or URL, default
+arguments and the environment variables, headers and parameters they need.
+'mcp-plugin install <name>' installs a catalog entry, prompting for anything
+required that was not given with --env, --header or --param.
+
+A catalog is bundled with mcp-plugin; 'catalog update' downloads a newer
+one. Overlay files add entries or replace entries of the same name, later
+files winning:
+  $MCP_PLUGIN_CATALOG        files separated like PATH
+  <config-dir>/mcp-plugin/catalog.yaml
+  mcp-plugin.catalog.yaml    next to the project's .mcp.json
+
+  servers:
+    internal-docs:
+      description: Company documentation search
+      transport: http
+      url: https://mcp.corp.example/docs
+      headers:
+        - name: Authorization
+          description: Corp SSO token
+          required: true
+          secret: true
+          format: Bearer {value}
+    jira:
+      description: Jira via the internal build
+      package: "@corp/jira-mcp"
+      args: ["--site", "{{SITE}}"]
+      params:
+        - name: SITE
+          default: corp.atlassian.net
+
+An entry of the project's mcp-plugin.catalog.yaml that replaces another is
+installed only when named with --catalog or confirmed at a prompt, since it
+comes with the repository rather than from the user.`,
+	}
+
+	cmd.AddCommand(newCatalogListCmd())
+	cmd.AddCommand(newCatalogShowCmd())
+	cmd.AddCommand(newCatalogUpdateCmd())
+	return cmd
+}
+
+func newCatalogListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list [query]",
+		Short: "List catalog entries",
+		Long: `List the catalog entries, or those whose name, description or package
+contains query.
+
+Examples:
+  mcp-plugin catalog list
+  mcp-plugin catalog list search -o table`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCatalogList(strings.Join(args, ""))
+		},
+	}
+}
+
+func newCatalogShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <name>",
+		Short: "Show a catalog entry",
+		Long: `Show a catalog entry: what it runs and the values it needs.
+
+Examples:
+  mcp-plugin catalog show github
+  mcp-plugin catalog show supabase -o yaml`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCatalogShow(args[0])
+		},
+	}
+}
+
+func newCatalogUpdateCmd() *cobra.Command {
+	var (
+		from  string
+		reset bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Download the latest catalog",
+		Long: `Download the latest catalog and use it instead of the bundled one. The
+download is checked before it replaces the current catalog. Overlays are not
+touched.
+
+Examples:
+  mcp-plugin catalog update
+  mcp-plugin catalog update --from https://mcp.corp.example/catalog.yaml
+  mcp-plugin catalog update --reset   # go back to the bundled catalog`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if reset {
+				return resetCatalog()
+			}
+			return runCatalogUpdate(cmd.Context(), from)
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", catalog.DefaultURL, "URL to download the catalog from")
+	cmd.Flags().BoolVar(&reset, "reset", false, "Remove the downloaded catalog and use the bundled one")
+	cmd.MarkFlagsMutuallyExclusive("from", "reset")
+	return cmd
+}
+
+// catalogView is the structured form of `catalog list`.
+type catalogView struct {
+	Sources []string            `json:"sources"`
+	Servers []*catalog.Template `json:"servers"`
+}
+
+func runCatalogList(query string) error {
+	cat, err := loadCatalog()
+	if err != nil {
+		return err
+	}
+	servers := cat.List()
+	if query != "" {
+		servers = cat.Search(query)
+	}
+
+	view := catalogView{Sources: catalogSources(), Servers: servers}
+	if view.Servers == nil {
+		view.Servers = []*catalog.Template{}
+	}
+	if handled, err := render("Catalog", view, func() table {
+		t := table{header: []string{"NAME", "TRANSPORT", "PACKAGE", "DESCRIPTION", "SOURCE"}}
+		for _, s := range servers {
+			t.rows = append(t.rows, []string{s.Name, templateTransport(s), orDash(templateTarget(s)), s.Description, s.Source})
+		}
+		return t
+	}); handled {
+		return err
+	}
+
+	if len(servers) == 0 {
+		fmt.Printf("No catalog entries match '%s'.\n", query)
+		return nil
+	}
+	width := 0
+	for _, s := range servers {
+		width = max(width, len(s.Name))
+	}
+	for _, s := range servers {
+		note := ""
+		switch {
+		case s.Replaces != "":
+			note = fmt.Sprintf(" (%s, replaces %s)", s.Source, s.Replaces)
+		case s.Source != catalog.Bundled && s.Source != catalogCachePath():
+			note = fmt.Sprintf(" (%s)", s.Source)
+		}
+		fmt.Printf("📦 %-*s  %s%s\n", width, s.Name, s.Description, note)
+	}
+	fmt.Printf("\nInstall with 'mcp-plugin install <name>'; see 'mcp-plugin catalog show <name>' for what it needs.\n")
+	return nil
+}
+
+func runCatalogShow(name string) error {
+	cat, err := loadCatalog()
+	if err != nil {
+		return err
+	}
+	t, ok := cat.Get(name)
+	if !ok {
+		return fmt.Errorf("no catalog entry '%s' (see 'mcp-plugin catalog list')", name)
+	}
+
+	if handled, err := render("CatalogEntry", t, func() table {
+		tbl := table{header: []string{"KIND", "NAME", "REQUIRED", "DEFAULT", "DESCRIPTION"}}
+		for _, in := range t.Inputs() {
+			tbl.rows = append(tbl.rows, []string{string(in.Kind), in.Name, fmt.Sprint(in.Required), orDash(in.Default), orDash(in.Description)})
+		}
+		return tbl
+	}); handled {
+		return err
+	}
+
+	fmt.Printf("📦 %s\n", t.Name)
+	fmt.Printf("  Description: %s\n", t.Description)
+	if t.Homepage != "" {
+		fmt.Printf("  Homepage: %s\n", t.Homepage)
+	}
+	fmt.Printf("  Transport: %s\n", templateTransport(t))
+	if target := templateTarget(t); target != "" {
+		fmt.Printf("  Package: %s\n", target)
+	}
+	if t.URL != "" {
+		fmt.Printf("  URL: %s\n", t.URL)
+	}
+	if len(t.Args) > 0 {
+		fmt.Printf("  Args: %s\n", strings.Join(t.Args, " "))
+	}
+	if t.Source != catalog.Bundled {
+		fmt.Printf("  Source: %s\n", t.Source)
+	}
+	if t.Replaces != "" {
+		fmt.Printf("  Replaces: the entry in %s\n", t.Replaces)
+	}
+
+	if inputs := t.Inputs(); len(inputs) > 0 {
+		fmt.Println("\nValues:")
+		for _, in := range inputs {
+			var notes []string
+			if in.Required {
+				notes = append(notes, "required")
+			}
+			if in.Secret {
+				notes = append(notes, "secret")
+			}
+			if in.Default != "" {
+				notes = append(notes, "default "+in.Default)
+			}
+			line := fmt.Sprintf("  %-6s %s", in.Kind, in.Name)
+			if len(notes) > 0 {
+				line += " (" + strings.Join(notes, ", ") + ")"
+			}
+			if in.Description != "" {
+				line += ": " + in.Description
+			}
+			fmt.Println(line)
+		}
+	}
+
+	fmt.Printf("\nInstall: mcp-plugin install %s", t.Name)
+	for _, in := range t.Missing(catalog.Values{}) {
+		fmt.Printf(" %s", inputFlag(in, "..."))
+	}
+	fmt.Println()
+	return nil
+}
+
+func runCatalogUpdate(ctx context.Context, url string) error {
+	data, err := catalog.Fetch(ctx, url)
+	if err != nil {
+		return err
+	}
+	path := catalogCachePath()
+	if err := catalog.Save(path, data); err != nil {
+		return fmt.Errorf("failed to save catalog: %w", err)
+	}
+	servers, _ := catalog.Parse(data, path)
+	fmt.Printf("✅ Catalog updated: %d server(s) from %s\n", len(servers), url)
+	return nil
+}
+
+func resetCatalog() error {
+	if err := os.Remove(catalogCachePath()); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove downloaded catalog: %w", err)
+	}
+	fmt.Println("Using the bundled catalog.")
+	return nil
+}
+
+// catalogCachePath is where `catalog update` saves the downloaded catalog.
+func catalogCachePath() string {
+	return filepath.Join(cacheDir(), "catalog.yaml")
+}
+
+// catalogOverlays are the overlay files, lowest precedence first.
+func catalogOverlays() []string {
+	var paths []string
+	for _, p := range filepath.SplitList(os.Getenv(catalog.OverlayEnv)) {
+		if p != "" {
+			paths = append(paths, p)
+		}
+	}
+	return append(paths,
+		filepath.Join(newWriter().StateDir(), "catalog.yaml"),
+		repoCatalogPath())
+}
+
+// repoCatalogPath is the overlay that comes with the project's repository.
+func repoCatalogPath() string {
+	return filepath.Join(projectDir(), catalog.RepoFileName)
+}
+
+// catalogSources lists the catalog in use and the overlays that exist.
+func catalogSources() []string {
+	sources := []string{catalog.Bundled}
+	if _, err := os.Stat(catalogCachePath()); err == nil {
+		sources[0] = catalogCachePath()
+	}
+	for _, p := range catalogOverlays() {
+		if _, err := os.Stat(p); err == nil {
+			sources = append(sources, p)
+		}
+	}
+	return sources
+}
+
+func loadCatalog() (*catalog.Catalog, error) {
+	return catalog.Load(catalogCachePath(), catalogOverlays()...)
+}
+
+func templateTransport(t *catalog.Template) string {
+	return cmp.Or(t.Transport, catalog.TransportStdio)
+}
+
+// templateTarget is what a stdio entry runs: its package, noting PyPI, or
+// its command.
+func templateTarget(t *catalog.Template) string {
+	switch {
+	case t.Command != "":
+		return t.Command
+	case t.Registry == catalog.RegistryPyPI:
+		return t.Package + " (pypi)"
+	default:
+		return t.Package
+	}
+}
+
+// inputFlag is the install flag that supplies in.
+func inputFlag(in catalog.Input, value string) string {
+	switch in.Kind {
+	case catalog.KindEnv:
+		return fmt.Sprintf("--env %s=%s", in.Name, value)
+	case catalog.KindHeader:
+		return fmt.Sprintf("--header '%s: %s'", in.Name, in.Write(value))
+	default:
+		return fmt.Sprintf("--param %s=%s", in.Name, value)
+	}
+}
+
+// expandTemplate builds the entry for t from the install flags, prompting
+// for missing required values when stdin is a terminal.
+func expandTemplate(t *catalog.Template, env map[string]string, headerFlags, paramFlags []string) (config.MCPServerEntry, error) {
+	if stdio := templateTransport(t) == catalog.TransportStdio; stdio && len(headerFlags) > 0 {
+		return config.MCPServerEntry{}, fmt.Errorf("--header applies only to HTTP servers")
+	} else if !stdio && len(env) > 0 {
+		return config.MCPServerEntry{}, fmt.Errorf("--env and --env-file apply only to command servers")
+	}
+
+	values := catalog.Values{Env: env, Params: map[string]string{}}
+	headers, err := parseHeaders(headerFlags)
+	if err != nil {
+		return config.MCPServerEntry{}, err
+	}
+	values.Headers = headers
+	for _, p := range paramFlags {
+		key, value, ok := strings.Cut(p, "=")
+		if !ok || key == "" {
+			return config.MCPServerEntry{}, fmt.Errorf("invalid parameter %q: expected NAME=VALUE", p)
+		}
+		values.Params[key] = value
+	}
+
+	if missing := t.Missing(values); len(missing) > 0 {
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			flags := make([]string, len(missing))
+			for i, in := range missing {
+				flags[i] = inputFlag(in, "...")
+			}
+			return config.MCPServerEntry{}, fmt.Errorf("catalog entry '%s' needs values that were not given: %s", t.Name, strings.Join(flags, " "))
+		}
+		if err := promptInputs(missing, values); err != nil {
+			return config.MCPServerEntry{}, err
+		}
+	}
+	return t.Expand(values)
+}
+
+// confirmReplacement warns that t overrides an entry of the same name from
+// another source. An entry of the repository's overlay arrives with a clone,
+// so it is installed only when named with --catalog (explicit) or confirmed
+// on the terminal.
+func confirmReplacement(t *catalog.Template, explicit bool) error {
+	fmt.Fprintf(os.Stderr, "⚠️  Catalog entry '%s' comes from %s and replaces the one in %s\n", t.Name, t.Source, t.Replaces)
+	if explicit || t.Source != repoCatalogPath() {
+		return nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return fmt.Errorf("catalog entry '%s' from %s replaces the one in %s; pass --catalog %s to install it", t.Name, t.Source, t.Replaces, t.Name)
+	}
+	fmt.Fprint(os.Stderr, "Install it anyway? [y/N]: ")
+	line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	if answer := strings.ToLower(strings.TrimSpace(line)); answer != "y" && answer != "yes" {
+		return fmt.Errorf("installation cancelled")
+	}
+	return nil
+}
+
+// promptInputs asks for each missing value on the terminal. Secret values
+// are not echoed.
+func promptInputs(missing []catalog.Input, values catalog.Values) error {
+	in := bufio.NewReader(os.Stdin)
+	hinted := false
+	for _, input := range missing {
+		if input.Secret && !hinted {
+			fmt.Fprintln(os.Stderr, "Secret values may be references such as ${keyring:NAME} or ${env:NAME} (see 'mcp-plugin secret --help').")
+			hinted = true
+		}
+		label := fmt.Sprintf("%s %s", input.Kind, input.Name)
+		if input.Description != "" {
+			label += " (" + input.Description + ")"
+		}
+		fmt.Fprintf(os.Stderr, "%s: ", label)
+
+		var answer string
+		if input.Secret {
+			b, err := term.ReadPassword(int(os.Stdin.Fd()))
+			fmt.Fprintln(os.Stderr)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", input.Name, err)
+			}
+			answer = string(b)
+		} else {
+			line, err := in.ReadString('\n')
+			if err != nil && line == "" {
+				return fmt.Errorf("failed to read %s: %w", input.Name, err)
+			}
+			answer = line
+		}
+		answer = strings.TrimSpace(answer)
+		if answer == "" {
+			return fmt.Errorf("%s %s is required", input.Kind, input.Name)
+		}
+
+		value := input.Write(answer)
+		switch input.Kind {
+		case catalog.KindEnv:
+			values.Env[input.Name] = value
+		case catalog.KindHeader:
+			values.Headers[input.Name] = value
+		default:
+			values.Params[input.Name] = value
+		}
+	}
+	return nil
+}
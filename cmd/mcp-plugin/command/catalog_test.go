@@ -0,0 +1,42 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/catalog"
+)
+
+func TestInstallTemplate_RepoOverlayReplacingBundled(t *testing.T) {
+	home := testHome(t, "{}")
+	homeDirFlag = home
+	t.Cleanup(func() { homeDirFlag, installCatalog = "", "" })
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if err := os.WriteFile(filepath.Join(dir, ".mcp.json"), []byte("{}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	overlay := "servers: {github: {description: look-alike, package: evil-github-mcp}, internal: {description: internal, package: '@corp/internal'}}"
+	if err := os.WriteFile(filepath.Join(dir, catalog.RepoFileName), []byte(overlay), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Stdin is not a terminal under go test, so there is no prompt.
+	if _, err := installTemplate([]string{"github"}); err == nil {
+		t.Fatal("installTemplate(github) error = nil, want the repository's replacement refused")
+	}
+	if tmpl, err := installTemplate([]string{"internal"}); err != nil || tmpl == nil || tmpl.Package != "@corp/internal" {
+		t.Errorf("installTemplate(internal) = %+v, %v; want the new overlay entry", tmpl, err)
+	}
+
+	installCatalog = "github"
+	tmpl, err := installTemplate([]string{"github"})
+	if err != nil || tmpl == nil || tmpl.Package != "evil-github-mcp" || tmpl.Replaces != catalog.Bundled {
+		t.Errorf("installTemplate(github --catalog github) = %+v, %v; want the overlay entry", tmpl, err)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultCheckConcurrency = 8
+	defaultCheckDeadline    = 2 * time.Minute
+)
+
+// checkFlags bounds the network checks of `server status --health` and
+// `config validate`.
+type checkFlags struct {
+	concurrency int
+	timeout     time.Duration // per check; zero means the default for its kind
+	deadline    time.Duration // for all checks together; zero means none
+}
+
+func addCheckFlags(cmd *cobra.Command, f *checkFlags, timeoutUsage string) {
+	cmd.Flags().IntVar(&f.concurrency, "concurrency", defaultCheckConcurrency, "Maximum number of servers checked at once")
+	cmd.Flags().DurationVar(&f.timeout, "timeout", 0, timeoutUsage)
+	cmd.Flags().DurationVar(&f.deadline, "deadline", defaultCheckDeadline, "Time limit for all checks together (0 for none)")
+}
+
+func (f checkFlags) validate() error {
+	if f.concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+	if f.timeout < 0 || f.deadline < 0 {
+		return fmt.Errorf("--timeout and --deadline must not be negative")
+	}
+	return nil
+}
+
+// timeoutOr returns the per-check timeout, or def when none was given.
+func (f checkFlags) timeoutOr(def time.Duration) time.Duration {
+	if f.timeout > 0 {
+		return f.timeout
+	}
+	return def
+}
+
+// withDeadline derives the context all checks share.
+func (f checkFlags) withDeadline(parent context.Context) (context.Context, context.CancelFunc) {
+	if f.deadline > 0 {
+		return context.WithTimeout(parent, f.deadline)
+	}
+	return context.WithCancel(parent)
+}
+
+// runChecks calls check for every item, at most limit at a time, and returns
+// the results in the order of items. Once ctx is done the remaining checks
+// still run but see a done context, so they return at once.
+func runChecks[T, R any](ctx context.Context, items []T, limit int, check func(context.Context, T) R) []R {
+	results := make([]R, len(items))
+	sem := make(chan struct{}, limit)
+
+	var wg sync.WaitGroup
+	for i, item := range items {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			results[i] = check(ctx, item)
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// interrupted reports a check run cut short by Ctrl-C, given the command's
+// context.
+func interrupted(ctx context.Context) error {
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return fmt.Errorf("interrupted: results above are incomplete")
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package command
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// testHome creates a home directory holding ~/.claude.json with the given
+// content.
+func testHome(t *testing.T, claudeJSON string) string {
+	t.Helper()
+	t.Setenv(config.ConfigDirEnv, "")
+	home := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(home, ".claude"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".claude.json"), []byte(claudeJSON), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return home
+}
+
+// runCommand runs the root command with args against home, restoring every
+// flag afterwards.
+func runCommand(t *testing.T, home string, args ...string) error {
+	t.Helper()
+	t.Cleanup(func() {
+		outputFormat, homeDirFlag, configDirFlag = outputText, "", ""
+		resetFlags(rootCmd)
+		rootCmd.SetArgs(nil)
+	})
+	t.Chdir(t.TempDir())
+	rootCmd.SetArgs(append([]string{"--home", home}, args...))
+	return rootCmd.ExecuteContext(context.Background())
+}
+
+// resetFlags puts the flags of cmd and its subcommands back to their
+// defaults; cobra keeps parsed values between executions.
+func resetFlags(cmd *cobra.Command) {
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if s, ok := f.Value.(pflag.SliceValue); ok {
+			_ = s.Replace(nil)
+		} else {
+			_ = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	})
+	for _, sub := range cmd.Commands() {
+		resetFlags(sub)
+	}
+}
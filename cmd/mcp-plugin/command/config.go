@@ -4,13 +4,17 @@
 package command
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/config"
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/policy"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 // exportFilePerm is owner-only; exports may include auth headers.
@@ -28,6 +32,9 @@ func newConfigCmd() *cobra.Command {
 	cmd.AddCommand(newConfigExportCmd())
 	cmd.AddCommand(newConfigImportCmd())
 	cmd.AddCommand(newConfigValidateCmd())
+	cmd.AddCommand(newConfigDiffCmd())
+	cmd.AddCommand(newConfigBackupsCmd())
+	cmd.AddCommand(newConfigRollbackCmd())
 
 	return cmd
 }
@@ -37,7 +44,7 @@ func newConfigShowCmd() *cobra.Command {
 		Use:   "show",
 		Short: "Show current configuration",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			reader := config.NewReader()
+			reader := newReader()
 
 			servers, err := reader.ListMCPServers()
 			if err != nil {
@@ -51,6 +58,22 @@ func newConfigShowCmd() *cobra.Command {
 				}
 			}
 
+			summary := configSummary{
+				Total:    len(servers),
+				Enabled:  enabledCount,
+				Disabled: len(servers) - enabledCount,
+			}
+			if handled, err := render("ConfigSummary", summary, func() table {
+				return table{
+					header: []string{"TOTAL", "ENABLED", "DISABLED"},
+					rows: [][]string{{
+						strconv.Itoa(summary.Total), strconv.Itoa(summary.Enabled), strconv.Itoa(summary.Disabled),
+					}},
+				}
+			}); handled {
+				return err
+			}
+
 			fmt.Printf("MCP Configuration Summary:\n")
 			fmt.Printf("  Total servers: %d\n", len(servers))
 			fmt.Printf("  Enabled: %d\n", enabledCount)
@@ -61,18 +84,53 @@ func newConfigShowCmd() *cobra.Command {
 	}
 }
 
+// configSummary is the structured form of `config show`.
+type configSummary struct {
+	Total    int `json:"total"`
+	Enabled  int `json:"enabled"`
+	Disabled int `json:"disabled"`
+}
+
+// configPaths is the structured form of `config paths`.
+type configPaths struct {
+	Override  string   `json:"override,omitempty"` // Flag or variable relocating the config
+	ClaudeDir string   `json:"claude_dir"`
+	Files     []string `json:"files"`
+	BackupDir string   `json:"backup_dir"`
+}
+
 func newConfigPathsCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "paths",
 		Short: "Show configuration file paths",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			reader := config.NewReader()
-			paths := reader.GetConfigPaths()
+			reader := newReader()
+			paths := configPaths{
+				Override:  configOverride(),
+				ClaudeDir: reader.ClaudeDir(),
+				Files:     reader.GetConfigPaths(),
+				BackupDir: newWriter().BackupDir(),
+			}
+			if handled, err := render("ConfigPaths", paths, func() table {
+				t := table{header: []string{"KIND", "PATH"}}
+				t.rows = append(t.rows, []string{"claude_dir", paths.ClaudeDir})
+				for _, p := range paths.Files {
+					t.rows = append(t.rows, []string{"config", p})
+				}
+				t.rows = append(t.rows, []string{"backups", paths.BackupDir})
+				return t
+			}); handled {
+				return err
+			}
 
+			if paths.Override != "" {
+				fmt.Printf("Config directory: %s (from %s)\n\n", paths.ClaudeDir, paths.Override)
+			}
 			fmt.Println("Configuration file paths:")
-			for _, p := range paths {
+			for _, p := range paths.Files {
 				fmt.Printf("  %s\n", p)
 			}
+			fmt.Printf("\nBackup directory:\n  %s\n", paths.BackupDir)
 
 			return nil
 		},
@@ -83,16 +141,20 @@ func newConfigPathsCmd() *cobra.Command {
 type ExportConfig struct {
 	Version    string                           `json:"version"`
 	ExportedAt string                           `json:"exportedAt"` //nolint:tagliatelle // external protocol wire format
+	Scope      string                           `json:"scope,omitempty"`
 	Servers    map[string]config.MCPServerEntry `json:"servers"`
 }
 
 func newConfigExportCmd() *cobra.Command {
-	var outputFile string
+	var file string
+	var scope string
+	var secrets string
+	var redact bool
 
 	cmd := &cobra.Command{
 		Use:   "export",
 		Short: "Export MCP configuration to file",
-		Long: `Export all MCP server configurations to a JSON file.
+		Long: `Export all MCP server configurations to a JSON or YAML file.
 
 The exported file can be used to:
 - Backup your configuration
@@ -104,39 +166,93 @@ Examples:
   mcp-plugin config export
 
   # Export to file
-  mcp-plugin config export -o mcp-backup.json`,
+  mcp-plugin config export -f mcp-backup.json
+
+  # Export as YAML
+  mcp-plugin config export -o yaml -f mcp-backup.yaml
+
+  # Export the servers of the current project only
+  mcp-plugin config export --scope local
+
+  # Share with teammates: credentials become ${env:NAME} references
+  mcp-plugin config export --secrets ref -f team-servers.json
+
+Env values, credential-like headers (Authorization, *token*, *key*), the
+values of credential-like flags in args (--api-key=..., --token ...) and
+passwords or credential-like query parameters in URLs, including URLs given
+as args, are written as-is by default. --secrets redact replaces them with
+"REDACTED"; --secrets ref replaces them with ${env:NAME} references that
+'config import' turns into variables Claude Code expands at launch. Values
+that already are references are kept in every mode. Other positional args are
+exported unchanged, so review exports of servers that take a secret as a
+bare argument. 'config import' skips servers that still hold "REDACTED".
+
+The global --output flag picks the format: json (the default) or yaml.
+'config import' reads both.`,
+		// Replaces the root hook so `config export -o FILE`, from before -o
+		// became the global format flag, keeps writing FILE.
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if validateOutputFormat() == nil || file != "" {
+				return validateOutputFormat()
+			}
+			warnf("config export -o FILE is deprecated; use --file %s", outputFormat)
+			file, outputFormat = outputFormat, outputJSON
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runConfigExport(outputFile)
+			if redact {
+				if cmd.Flags().Changed("secrets") && secrets != secretsRedact {
+					return fmt.Errorf("--redact conflicts with --secrets %s", secrets)
+				}
+				secrets = secretsRedact
+			}
+			return runConfigExport(file, scope, secrets)
 		},
 	}
 
-	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file (default: stdout)")
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Output file (default: stdout)")
+	cmd.Flags().StringVar(&secrets, "secrets", secretsPlain, "How to write credentials: plain, redact or ref")
+	cmd.Flags().BoolVar(&redact, "redact", false, "Same as --secrets redact")
+	addScopeFlag(cmd, &scope)
 
 	return cmd
 }
 
-func runConfigExport(outputFile string) error {
-	writer := config.NewWriter()
+func runConfigExport(outputFile, scope, secrets string) error {
+	if outputFormat == outputTable {
+		return fmt.Errorf("config export writes json or yaml, not %s", outputFormat)
+	}
+
+	writer, err := scopedWriter(scope)
+	if err != nil {
+		return err
+	}
 
-	servers, err := writer.ListMCPServersGlobal()
+	servers, err := writer.ListMCPServerEntries()
 	if err != nil {
 		return fmt.Errorf("failed to read servers: %w", err)
 	}
 
+	servers, err = exportSecrets(servers, secrets)
+	if err != nil {
+		return err
+	}
+
 	export := ExportConfig{
 		Version:    "1.0",
 		ExportedAt: time.Now().Format(time.RFC3339),
+		Scope:      string(writer.Scope()),
 		Servers:    servers,
 	}
 
-	output, err := json.MarshalIndent(export, "", "  ")
+	output, err := marshalExport(export)
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
 
 	if outputFile == "" {
-		fmt.Println(string(output))
-		return nil
+		_, err := os.Stdout.Write(output)
+		return err
 	}
 
 	if err := os.WriteFile(outputFile, output, exportFilePerm); err != nil {
@@ -150,6 +266,7 @@ func runConfigExport(outputFile string) error {
 func newConfigImportCmd() *cobra.Command {
 	var merge bool
 	var dryRun bool
+	var scope string
 
 	cmd := &cobra.Command{
 		Use:   "import <file>",
@@ -157,7 +274,10 @@ func newConfigImportCmd() *cobra.Command {
 		Long: `Import MCP server configurations from a JSON file.
 
 By default, import will fail if servers already exist.
-Use --merge to update existing servers.
+Use --merge to update existing servers. Servers are imported into the scope
+given by --scope (user by default), regardless of the scope they were
+exported from. Servers a policy does not allow are skipped (see
+'mcp-plugin policy --help').
 
 Examples:
   # Import from file
@@ -167,29 +287,32 @@ Examples:
   mcp-plugin config import mcp-backup.json --dry-run
 
   # Merge with existing config
-  mcp-plugin config import mcp-backup.json --merge`,
+  mcp-plugin config import mcp-backup.json --merge
+
+  # Import into the repository's .mcp.json
+  mcp-plugin config import team-servers.json --scope project`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runConfigImport(args[0], merge, dryRun)
+			return runConfigImport(args[0], scope, merge, dryRun)
 		},
 	}
 
 	cmd.Flags().BoolVar(&merge, "merge", false, "Merge with existing configuration (update existing servers)")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be imported without making changes")
+	addScopeFlag(cmd, &scope)
 
 	return cmd
 }
 
-func runConfigImport(inputFile string, merge, dryRun bool) error {
-	// #nosec G304 -- inputFile is an intentional user-provided CLI path
-	data, err := os.ReadFile(inputFile)
+func runConfigImport(inputFile, scope string, merge, dryRun bool) error {
+	writer, err := scopedWriter(scope)
 	if err != nil {
-		return fmt.Errorf("failed to read file: %w", err)
+		return err
 	}
 
-	var importConfig ExportConfig
-	if err := json.Unmarshal(data, &importConfig); err != nil {
-		return fmt.Errorf("failed to parse config: %w", err)
+	importConfig, err := readExportFile(inputFile)
+	if err != nil {
+		return err
 	}
 
 	if len(importConfig.Servers) == 0 {
@@ -197,13 +320,17 @@ func runConfigImport(inputFile string, merge, dryRun bool) error {
 		return nil
 	}
 
-	writer := config.NewWriter()
-	existingServers, err := writer.ListMCPServersGlobal()
+	existingServers, err := writer.ListMCPServerEntries()
 	if err != nil {
 		existingServers = map[string]config.MCPServerEntry{}
 	}
 
-	added, updated, skipped := applyImport(writer, importConfig.Servers, existingServers, merge, dryRun)
+	policies, err := loadPolicies()
+	if err != nil {
+		return err
+	}
+
+	added, updated, skipped := applyImport(writer, importConfig.Servers, existingServers, policies, merge, dryRun)
 
 	if dryRun {
 		fmt.Printf("\nDry run summary: %d to add, %d to update, %d to skip\n", added, updated, skipped)
@@ -214,13 +341,75 @@ func runConfigImport(inputFile string, merge, dryRun bool) error {
 	return nil
 }
 
+// marshalExport encodes an export in the format of the global --output flag.
+func marshalExport(export ExportConfig) ([]byte, error) {
+	if outputFormat == outputYAML {
+		var buf bytes.Buffer
+		if err := encodeYAML(&buf, export); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// readExportFile reads a file written by `config export`, as JSON or YAML.
+func readExportFile(path string) (ExportConfig, error) {
+	// #nosec G304 -- path is an intentional user-provided CLI path
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ExportConfig{}, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var export ExportConfig
+	if err := json.Unmarshal(data, &export); err == nil {
+		return export, nil
+	}
+
+	// YAML is a superset of JSON: decode it and read it back as JSON so the
+	// server entries keep their field handling.
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return ExportConfig{}, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if data, err = json.Marshal(doc); err != nil {
+		return ExportConfig{}, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if err := json.Unmarshal(data, &export); err != nil {
+		return ExportConfig{}, fmt.Errorf("failed to parse config: %w", err)
+	}
+	return export, nil
+}
+
 func applyImport(
 	writer *config.Writer,
 	servers map[string]config.MCPServerEntry,
 	existing map[string]config.MCPServerEntry,
+	policies policy.Set,
 	merge, dryRun bool,
 ) (added, updated, skipped int) {
 	for name, entry := range servers {
+		if hasRedacted(entry) {
+			fmt.Printf("⚠️  Skipped %s: holds %s placeholders; fill in the values or export with --secrets ref\n", name, redactedValue)
+			skipped++
+			continue
+		}
+		entry, err := prepareSecrets(entry)
+		if err != nil {
+			fmt.Printf("⚠️  Skipped %s: %v\n", name, err)
+			skipped++
+			continue
+		}
+		if err := checkPolicy(policies, name, writer.Scope(), entry); err != nil {
+			fmt.Printf("⛔ Skipped %v\n", err)
+			skipped++
+			continue
+		}
+
 		_, exists := existing[name]
 		if dryRun {
 			a, u, s := dryRunImportEntry(name, exists, merge)
@@ -262,11 +451,7 @@ func applyImportEntry(
 			fmt.Printf("⚠️  Skipped %s (already exists, use --merge to update)\n", name)
 			return 0, 0, 1
 		}
-		if err := writer.RemoveMCPServer(name); err != nil {
-			fmt.Printf("⚠️  Failed to update %s: %v\n", name, err)
-			return 0, 0, 0
-		}
-		if err := writer.AddMCPServer(name, entry); err != nil {
+		if err := writer.UpdateMCPServer(name, entry); err != nil {
 			fmt.Printf("⚠️  Failed to update %s: %v\n", name, err)
 			return 0, 0, 0
 		}
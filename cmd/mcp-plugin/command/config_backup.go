@@ -0,0 +1,125 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package command
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+func newConfigBackupsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backups",
+		Short: "Manage configuration backups",
+		Long: `Manage automatic configuration backups.
+
+Every change made by mcp-plugin snapshots the previous version of the
+modified file (~/.claude.json or ~/.claude/settings.json). The most recent
+snapshots are kept; older ones are pruned automatically.
+
+Examples:
+  # List available backups
+  mcp-plugin config backups list
+
+  # Restore the most recent backup
+  mcp-plugin config rollback`,
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List configuration backups",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigBackupsList()
+		},
+	})
+
+	return cmd
+}
+
+func runConfigBackupsList() error {
+	writer := newWriter()
+
+	backups, err := writer.ListBackups()
+	if err != nil {
+		return err
+	}
+
+	if backups == nil {
+		backups = []config.Backup{}
+	}
+	if handled, err := render("BackupList", backups, func() table {
+		t := table{header: []string{"ID", "FILE", "CREATED", "SIZE"}}
+		for _, b := range backups {
+			t.rows = append(t.rows, []string{
+				b.ID, b.Path, b.CreatedAt.Local().Format(time.DateTime), strconv.FormatInt(b.Size, 10),
+			})
+		}
+		return t
+	}); handled {
+		return err
+	}
+
+	if len(backups) == 0 {
+		fmt.Println("No backups found.")
+		return nil
+	}
+
+	fmt.Printf("Found %d backup(s) in %s:\n\n", len(backups), writer.BackupDir())
+	for _, b := range backups {
+		fmt.Printf("  %s\n", b.ID)
+		fmt.Printf("    File: %s\n", b.Path)
+		fmt.Printf("    Created: %s (%d bytes)\n", b.CreatedAt.Local().Format(time.DateTime), b.Size)
+		if b.Restored != "" {
+			fmt.Printf("    Taken by rollback to: %s\n", b.Restored)
+		}
+	}
+
+	return nil
+}
+
+func newConfigRollbackCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rollback [backup-id]",
+		Short: "Restore configuration from a backup",
+		Long: `Restore a configuration file from a backup.
+
+Without an argument, the most recent change is undone; running it again
+undoes the change before that. A backup ID may be abbreviated to any unique
+prefix. The current file is backed up before it is replaced, so a rollback
+can itself be undone by restoring that backup's ID.
+
+Examples:
+  # Undo the last change
+  mcp-plugin config rollback
+
+  # Restore a specific backup
+  mcp-plugin config rollback 20250101-120000.000000-claude.json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var id string
+			if len(args) > 0 {
+				id = args[0]
+			}
+			return runConfigRollback(id)
+		},
+	}
+}
+
+func runConfigRollback(id string) error {
+	writer := newWriter()
+
+	backup, err := writer.Rollback(id)
+	if err != nil {
+		return fmt.Errorf("rollback failed: %w", err)
+	}
+
+	fmt.Printf("✅ Restored %s from backup %s\n", backup.Path, backup.ID)
+	fmt.Println("\nNote: Restart Claude Code for changes to take effect.")
+
+	return nil
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// Keywords accepted as `config diff` sources besides file paths.
+const (
+	diffSourceLive   = "live"
+	diffSourceBackup = "backup"
+)
+
+func newConfigDiffCmd() *cobra.Command {
+	var scope string
+
+	cmd := &cobra.Command{
+		Use:   "diff <a> <b>",
+		Short: "Show differences between configurations",
+		Long: `Compare the MCP servers of two configurations field by field.
+
+Each side can be:
+  live          the current configuration for --scope
+  backup[:id]   a backup from 'config backups list' (latest when no id is given)
+  <file>        a file written by 'config export'
+
+Header and env values are masked. Servers are compared within one scope;
+backups of ~/.claude.json are read for the user or local scope.
+
+Examples:
+  # What would importing a teammate's export change?
+  mcp-plugin config diff live team-servers.json
+
+  # What did the last write change?
+  mcp-plugin config diff backup live
+
+  # Compare the project's .mcp.json with a backup, for a review bot
+  mcp-plugin config diff backup:20250101-120000 live --scope project --output json`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigDiff(args[0], args[1], scope)
+		},
+	}
+
+	addScopeFlag(cmd, &scope)
+
+	return cmd
+}
+
+// configDiffView is the structured form of `config diff`.
+type configDiffView struct {
+	A       string              `json:"a"`
+	B       string              `json:"b"`
+	Scope   string              `json:"scope"`
+	Added   int                 `json:"added"`
+	Changed int                 `json:"changed"`
+	Removed int                 `json:"removed"`
+	Servers []config.ServerDiff `json:"servers"`
+}
+
+func runConfigDiff(a, b, scope string) error {
+	writer, err := scopedWriter(scope)
+	if err != nil {
+		return err
+	}
+
+	labelA, serversA, err := loadDiffSource(writer, a)
+	if err != nil {
+		return err
+	}
+	labelB, serversB, err := loadDiffSource(writer, b)
+	if err != nil {
+		return err
+	}
+
+	view := configDiffView{A: labelA, B: labelB, Scope: string(writer.Scope()), Servers: []config.ServerDiff{}}
+	for _, d := range config.DiffServers(serversA, serversB) {
+		switch d.Action {
+		case config.ActionCreate:
+			view.Added++
+		case config.ActionDelete:
+			view.Removed++
+		default:
+			view.Changed++
+		}
+		d.Fields = maskFieldChanges(d.Fields)
+		view.Servers = append(view.Servers, d)
+	}
+
+	if handled, err := render("ConfigDiff", view, func() table {
+		t := table{header: []string{"SERVER", "ACTION", "FIELD", "OLD", "NEW"}}
+		for _, d := range view.Servers {
+			for _, f := range d.Fields {
+				t.rows = append(t.rows, []string{d.Name, string(d.Action), f.Field, orDash(f.Old), orDash(f.New)})
+			}
+		}
+		return t
+	}); handled {
+		return err
+	}
+
+	fmt.Printf("--- %s\n+++ %s\n\n", view.A, view.B)
+	if len(view.Servers) == 0 {
+		fmt.Println("No differences.")
+		return nil
+	}
+	for _, d := range view.Servers {
+		fmt.Printf("  %s %s\n", actionSymbols[d.Action], d.Name)
+		for _, f := range d.Fields {
+			printFieldChange(f, "      ")
+		}
+	}
+	fmt.Printf("\nSummary: %d added, %d changed, %d removed\n", view.Added, view.Changed, view.Removed)
+	return nil
+}
+
+// loadDiffSource resolves one side of `config diff` to a label and servers.
+func loadDiffSource(writer *config.Writer, source string) (string, map[string]config.MCPServerEntry, error) {
+	switch {
+	case source == diffSourceLive:
+		servers, err := writer.ListMCPServerEntries()
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to read servers: %w", err)
+		}
+		return fmt.Sprintf("live (%s scope, %s)", writer.Scope(), writer.ServersPath()), servers, nil
+
+	case source == diffSourceBackup || strings.HasPrefix(source, diffSourceBackup+":"):
+		id := strings.TrimPrefix(strings.TrimPrefix(source, diffSourceBackup), ":")
+		backup, servers, err := writer.BackupServers(id)
+		if err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("backup %s (%s)", backup.ID, backup.Path), servers, nil
+
+	default:
+		export, err := readExportFile(source)
+		if err != nil {
+			return "", nil, err
+		}
+		if export.Servers == nil {
+			export.Servers = map[string]config.MCPServerEntry{}
+		}
+		return source, export.Servers, nil
+	}
+}
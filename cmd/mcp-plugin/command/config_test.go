@@ -0,0 +1,72 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package command
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigExport_OutputFormat(t *testing.T) {
+	home := testHome(t, `{"mcpServers": {"ctx": {"command": "npx", "args": ["-y", "ctx"]}}}`)
+
+	file := filepath.Join(t.TempDir(), "export.json")
+	if err := runCommand(t, home, "config", "export", "-o", "json", "-f", file); err != nil {
+		t.Fatalf("config export -o json: %v", err)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var export ExportConfig
+	if err := json.Unmarshal(data, &export); err != nil {
+		t.Fatalf("export is not JSON: %v\n%s", err, data)
+	}
+	if export.Servers["ctx"].Command != "npx" {
+		t.Errorf("servers = %+v, want ctx", export.Servers)
+	}
+
+	yamlFile := filepath.Join(t.TempDir(), "export.yaml")
+	if err := runCommand(t, home, "config", "export", "-o", "yaml", "-f", yamlFile); err != nil {
+		t.Fatalf("config export -o yaml: %v", err)
+	}
+	data, err = os.ReadFile(yamlFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.HasPrefix(string(data), "{") || !strings.Contains(string(data), "command: npx") {
+		t.Errorf("export is not YAML:\n%s", data)
+	}
+	export, err = readExportFile(yamlFile)
+	if err != nil || export.Servers["ctx"].Command != "npx" {
+		t.Errorf("readExportFile(yaml) = %+v, %v", export, err)
+	}
+
+	if err := runCommand(t, home, "config", "export", "-o", "table"); err == nil {
+		t.Error("config export -o table: error = nil")
+	}
+}
+
+func TestConfigExport_DeprecatedOutputFile(t *testing.T) {
+	home := testHome(t, `{"mcpServers": {"ctx": {"command": "npx", "args": ["-y", "ctx"]}}}`)
+
+	file := filepath.Join(t.TempDir(), "backup.json")
+	if err := runCommand(t, home, "config", "export", "-o", file); err != nil {
+		t.Fatalf("config export -o FILE: %v", err)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var export ExportConfig
+	if err := json.Unmarshal(data, &export); err != nil {
+		t.Fatalf("export is not JSON: %v\n%s", err, data)
+	}
+	if export.Servers["ctx"].Command != "npx" {
+		t.Errorf("servers = %+v, want ctx", export.Servers)
+	}
+}
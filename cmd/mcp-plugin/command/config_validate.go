@@ -5,19 +5,22 @@ package command
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"net/http"
 	"net/url"
 	"os/exec"
-	"strings"
 	"time"
 
 	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/config"
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/probe"
 	"github.com/spf13/cobra"
 )
 
 func newConfigValidateCmd() *cobra.Command {
-	var verbose bool
+	var (
+		verbose bool
+		flags   checkFlags
+	)
 
 	cmd := &cobra.Command{
 		Use:   "validate",
@@ -25,11 +28,14 @@ func newConfigValidateCmd() *cobra.Command {
 		Long: `Validate all MCP server configurations for common issues.
 
 Checks performed:
-- URL syntax and reachability (for HTTP servers)
+- URL syntax and an MCP handshake with the configured headers (for HTTP servers)
 - Command availability (for command-based servers)
 - Required fields presence
 - Duplicate server detection
 
+Servers are checked concurrently (--concurrency). Each HTTP handshake has its
+own --timeout, and --deadline bounds the whole run; Ctrl-C stops it early.
+
 Examples:
   # Quick validation
   mcp-plugin config validate
@@ -37,37 +43,74 @@ Examples:
   # Verbose validation with details
   mcp-plugin config validate --verbose`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runConfigValidate(verbose)
+			if err := flags.validate(); err != nil {
+				return err
+			}
+			return runConfigValidate(cmd.Context(), verbose, flags)
 		},
 	}
 
 	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Show detailed validation results")
+	addCheckFlags(cmd, &flags, "Time limit for each HTTP handshake (default 5s)")
 
 	return cmd
 }
 
 // ValidationResult represents a validation check result.
 type ValidationResult struct {
-	Server  string
-	Check   string
-	Status  string // "pass", "warn", "fail"
-	Message string
+	Server  string `json:"server"`
+	Check   string `json:"check"`
+	Status  string `json:"status"` // "pass", "warn", "fail"
+	Message string `json:"message"`
+}
+
+// validationReport is the structured form of `config validate`.
+type validationReport struct {
+	Servers  int                `json:"servers"`
+	Pass     int                `json:"pass"`
+	Warnings int                `json:"warnings"`
+	Failures int                `json:"failures"`
+	Results  []ValidationResult `json:"results"`
 }
 
-func runConfigValidate(verbose bool) error {
-	reader := config.NewReader()
+func runConfigValidate(ctx context.Context, verbose bool, flags checkFlags) error {
+	reader := newReader()
 
 	servers, err := reader.ListMCPServers()
 	if err != nil {
 		return fmt.Errorf("failed to read servers: %w", err)
 	}
 
-	if len(servers) == 0 {
+	if len(servers) == 0 && textOutput() {
 		fmt.Println("No MCP servers configured.")
 		return nil
 	}
 
-	results, passCount, warnCount, failCount := validateAllServers(servers)
+	checkCtx, cancel := flags.withDeadline(ctx)
+	defer cancel()
+	results, passCount, warnCount, failCount := validateAllServers(checkCtx, servers, flags)
+	if err := interrupted(ctx); err != nil {
+		return err
+	}
+
+	if results == nil {
+		results = []ValidationResult{}
+	}
+	report := validationReport{
+		Servers:  len(servers),
+		Pass:     passCount,
+		Warnings: warnCount,
+		Failures: failCount,
+		Results:  results,
+	}
+	if handled, err := render("ValidationReport", report, func() table {
+		return validationTable(results)
+	}); handled {
+		if err != nil {
+			return err
+		}
+		return validationError(failCount)
+	}
 
 	if verbose {
 		printValidationResults(results)
@@ -78,14 +121,31 @@ func runConfigValidate(verbose bool) error {
 	fmt.Printf("  ⚠️  Warnings: %d\n", warnCount)
 	fmt.Printf("  ❌ Failures: %d\n", failCount)
 
+	return validationError(failCount)
+}
+
+func validationError(failCount int) error {
 	if failCount > 0 {
 		return fmt.Errorf("validation failed with %d errors", failCount)
 	}
-
 	return nil
 }
 
-func validateAllServers(servers []config.MCPServer) (results []ValidationResult, passCount, warnCount, failCount int) {
+func validationTable(results []ValidationResult) table {
+	t := table{header: []string{"SERVER", "CHECK", "STATUS", "MESSAGE"}}
+	for _, r := range results {
+		t.rows = append(t.rows, []string{r.Server, r.Check, r.Status, r.Message})
+	}
+	return t
+}
+
+// serverValidation is the outcome of validating one server.
+type serverValidation struct {
+	results          []ValidationResult
+	pass, warn, fail int
+}
+
+func validateAllServers(ctx context.Context, servers []config.MCPServer, flags checkFlags) (results []ValidationResult, passCount, warnCount, failCount int) {
 	seen := make(map[string]string)
 	for _, server := range servers {
 		if existingSource, exists := seen[server.Name]; exists {
@@ -97,20 +157,26 @@ func validateAllServers(servers []config.MCPServer) (results []ValidationResult,
 			})
 			warnCount++
 		}
-		seen[server.Name] = server.Source
+		seen[server.Name] = fmt.Sprintf("%s scope, %s", server.Source, server.Path)
 	}
 
-	for _, server := range servers {
-		serverResults, p, w, f := validateOneServer(server)
-		results = append(results, serverResults...)
-		passCount += p
-		warnCount += w
-		failCount += f
+	validations := runChecks(ctx, servers, flags.concurrency, func(ctx context.Context, server config.MCPServer) serverValidation {
+		ctx, cancel := context.WithTimeout(ctx, flags.timeoutOr(validateProbeTimeout))
+		defer cancel()
+		var v serverValidation
+		v.results, v.pass, v.warn, v.fail = validateOneServer(ctx, server)
+		return v
+	})
+	for _, v := range validations {
+		results = append(results, v.results...)
+		passCount += v.pass
+		warnCount += v.warn
+		failCount += v.fail
 	}
 	return results, passCount, warnCount, failCount
 }
 
-func validateOneServer(server config.MCPServer) (results []ValidationResult, passCount, warnCount, failCount int) {
+func validateOneServer(ctx context.Context, server config.MCPServer) (results []ValidationResult, passCount, warnCount, failCount int) {
 	if server.Type == "" {
 		results = append(results, ValidationResult{
 			Server:  server.Name,
@@ -121,7 +187,7 @@ func validateOneServer(server config.MCPServer) (results []ValidationResult, pas
 		warnCount++
 	}
 
-	result, ok := typeSpecificValidation(server)
+	result, ok := typeSpecificValidation(ctx, server)
 	if !ok {
 		return results, passCount, warnCount, failCount
 	}
@@ -137,15 +203,15 @@ func validateOneServer(server config.MCPServer) (results []ValidationResult, pas
 	return results, passCount, warnCount, failCount
 }
 
-func typeSpecificValidation(server config.MCPServer) (ValidationResult, bool) {
+func typeSpecificValidation(ctx context.Context, server config.MCPServer) (ValidationResult, bool) {
 	switch server.Type {
 	case config.TypeHTTP:
-		return validateHTTPServer(server), true
+		return validateHTTPServer(ctx, server), true
 	case config.TypeCommand:
 		return validateCommandServer(server), true
 	default:
 		if server.URL != "" {
-			return validateHTTPServer(server), true
+			return validateHTTPServer(ctx, server), true
 		}
 		if server.Command != "" {
 			return validateCommandServer(server), true
@@ -158,21 +224,23 @@ func printValidationResults(results []ValidationResult) {
 	fmt.Println("Validation Results:")
 	fmt.Println("─────────────────────────────────")
 	for _, r := range results {
-		var icon string
-		switch r.Status {
-		case checkStatusWarn:
-			icon = "⚠️"
-		case checkStatusFail:
-			icon = "❌"
-		default:
-			icon = "✅"
-		}
-		fmt.Printf("%s %s [%s]: %s\n", icon, r.Server, r.Check, r.Message)
+		fmt.Printf("%s %s [%s]: %s\n", statusIcon(r.Status), r.Server, r.Check, r.Message)
 	}
 	fmt.Println()
 }
 
-func validateHTTPServer(server config.MCPServer) ValidationResult {
+func statusIcon(status string) string {
+	switch status {
+	case checkStatusWarn:
+		return "⚠️"
+	case checkStatusFail:
+		return "❌"
+	default:
+		return "✅"
+	}
+}
+
+func validateHTTPServer(ctx context.Context, server config.MCPServer) ValidationResult {
 	if server.URL == "" {
 		return ValidationResult{
 			Server:  server.Name,
@@ -201,58 +269,26 @@ func validateHTTPServer(server config.MCPServer) ValidationResult {
 		}
 	}
 
-	return checkHTTPReachability(server)
+	return checkHTTPReachability(ctx, server)
 }
 
-func checkHTTPReachability(server config.MCPServer) ValidationResult {
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
+// validateProbeTimeout is the default time limit of one HTTP handshake in
+// `config validate`.
+const validateProbeTimeout = 5 * time.Second
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodHead, server.URL, http.NoBody)
-	if err != nil {
-		return ValidationResult{
-			Server:  server.Name,
-			Check:   checkReachability,
-			Status:  checkStatusWarn,
-			Message: fmt.Sprintf("Cannot verify: %v", err),
-		}
-	}
+// checkHTTPReachability performs an MCP handshake with the configured
+// headers. An unreachable server is only a warning here, as it may simply be
+// offline; a URL that answers without speaking MCP is a failure.
+func checkHTTPReachability(ctx context.Context, server config.MCPServer) ValidationResult {
+	result := ValidationResult{Server: server.Name, Check: checkReachability}
 
-	client := &http.Client{Timeout: 3 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		if strings.Contains(err.Error(), "connection refused") {
-			return ValidationResult{
-				Server:  server.Name,
-				Check:   checkReachability,
-				Status:  checkStatusWarn,
-				Message: "Server unreachable (may be offline or firewalled)",
-			}
-		}
-		return ValidationResult{
-			Server:  server.Name,
-			Check:   checkReachability,
-			Status:  checkStatusWarn,
-			Message: fmt.Sprintf("Cannot verify: %v", err),
-		}
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode >= 400 && resp.StatusCode < 500 {
-		return ValidationResult{
-			Server:  server.Name,
-			Check:   checkReachability,
-			Status:  checkStatusPass,
-			Message: fmt.Sprintf("Reachable (HTTP %d - may require auth)", resp.StatusCode),
-		}
-	}
-
-	return ValidationResult{
-		Server:  server.Name,
-		Check:   checkReachability,
-		Status:  checkStatusPass,
-		Message: fmt.Sprintf("Reachable (HTTP %d)", resp.StatusCode),
+	info, err := probe.Handshake(ctx, server)
+	if errors.Is(err, probe.ErrUnreachable) {
+		result.Status, result.Message = checkStatusWarn, fmt.Sprintf("Server %v (may be offline or firewalled)", err)
+		return result
 	}
+	result.Status, result.Message = httpHealthStatus(info, err)
+	return result
 }
 
 func validateCommandServer(server config.MCPServer) ValidationResult {
@@ -14,4 +14,6 @@ const (
 
 	checkReachability = "reachability"
 	checkCommand      = "command"
+	checkHealth       = "health"
+	checkProbe        = "probe"
 )
@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/config"
 	"github.com/spf13/cobra"
 )
 
@@ -21,7 +20,8 @@ The plugin-id should be in the format "name@publisher", for example:
   - context7@claude-plugins-official
   - greptile@claude-plugins-official
 
-Use "mcp-plugin list" to see available plugins.`,
+Use "mcp-plugin list --plugins" to see available plugins and the MCP servers
+each one provides.`,
 		Args: cobra.ExactArgs(1),
 		RunE: runDisable,
 	}
@@ -37,37 +37,25 @@ func runDisable(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid plugin ID format: expected 'name@publisher', got '%s'", pluginID)
 	}
 
-	writer := config.NewWriter()
-
-	// Check current status
-	enabled, exists, err := writer.GetPluginStatus(pluginID)
+	plugin, err := findPlugin(pluginID)
 	if err != nil {
-		return fmt.Errorf("failed to check plugin status: %w", err)
-	}
-
-	if !exists {
-		plugins, listErr := writer.ListPlugins()
-		fmt.Printf("Plugin '%s' not found in settings.\n\n", pluginID)
-		if listErr == nil {
-			fmt.Println("Available plugins:")
-			for id := range plugins {
-				fmt.Printf("  - %s\n", id)
-			}
-		}
-		return fmt.Errorf("plugin not found")
+		return err
 	}
 
-	if !enabled {
+	if !plugin.Enabled {
 		fmt.Printf("Plugin '%s' is already disabled.\n", pluginID)
 		return nil
 	}
 
 	// Disable the plugin
-	if err := writer.SetPluginEnabled(pluginID, false); err != nil {
+	if err := newWriter().SetPluginEnabled(pluginID, false); err != nil {
 		return fmt.Errorf("failed to disable plugin: %w", err)
 	}
 
 	fmt.Printf("Plugin '%s' has been disabled.\n", pluginID)
+	if len(plugin.Servers) > 0 {
+		fmt.Printf("MCP servers: %s\n", strings.Join(plugin.Servers, ", "))
+	}
 	fmt.Println("Note: Restart Claude Code for changes to take effect.")
 
 	return nil
@@ -21,7 +21,8 @@ The plugin-id should be in the format "name@publisher", for example:
   - context7@claude-plugins-official
   - greptile@claude-plugins-official
 
-Use "mcp-plugin list" to see available plugins.`,
+Use "mcp-plugin list --plugins" to see available plugins and the MCP servers
+each one provides.`,
 		Args: cobra.ExactArgs(1),
 		RunE: runEnable,
 	}
@@ -37,38 +38,49 @@ func runEnable(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid plugin ID format: expected 'name@publisher', got '%s'", pluginID)
 	}
 
-	writer := config.NewWriter()
-
-	// Check current status
-	enabled, exists, err := writer.GetPluginStatus(pluginID)
+	plugin, err := findPlugin(pluginID)
 	if err != nil {
-		return fmt.Errorf("failed to check plugin status: %w", err)
-	}
-
-	if !exists {
-		plugins, listErr := writer.ListPlugins()
-		fmt.Printf("Plugin '%s' not found in settings.\n\n", pluginID)
-		if listErr == nil {
-			fmt.Println("Available plugins:")
-			for id := range plugins {
-				fmt.Printf("  - %s\n", id)
-			}
-		}
-		return fmt.Errorf("plugin not found")
+		return err
 	}
 
-	if enabled {
+	if plugin.Enabled {
 		fmt.Printf("Plugin '%s' is already enabled.\n", pluginID)
 		return nil
 	}
 
 	// Enable the plugin
-	if err := writer.SetPluginEnabled(pluginID, true); err != nil {
+	if err := newWriter().SetPluginEnabled(pluginID, true); err != nil {
 		return fmt.Errorf("failed to enable plugin: %w", err)
 	}
 
 	fmt.Printf("Plugin '%s' has been enabled.\n", pluginID)
+	if len(plugin.Servers) > 0 {
+		fmt.Printf("MCP servers: %s\n", strings.Join(plugin.Servers, ", "))
+	}
 	fmt.Println("Note: Restart Claude Code for changes to take effect.")
 
 	return nil
 }
+
+// findPlugin looks a plugin up in settings.json and the plugin cache. For an
+// unknown plugin it prints the IDs that are known.
+func findPlugin(id string) (config.Plugin, error) {
+	plugins, err := newReader().ListPlugins()
+	if err != nil {
+		return config.Plugin{}, fmt.Errorf("failed to check plugin status: %w", err)
+	}
+	for _, p := range plugins {
+		if p.ID == id {
+			return p, nil
+		}
+	}
+
+	fmt.Printf("Plugin '%s' not found in settings or the plugin cache.\n\n", id)
+	if len(plugins) > 0 {
+		fmt.Println("Available plugins:")
+		for _, p := range plugins {
+			fmt.Printf("  - %s\n", p.ID)
+		}
+	}
+	return config.Plugin{}, fmt.Errorf("plugin not found")
+}
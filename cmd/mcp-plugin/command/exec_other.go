@@ -0,0 +1,28 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+//go:build !unix
+
+package command
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+)
+
+// execProcess runs the server as a child with inherited stdio and exits with
+// its status, as exec(2) is not available.
+func execProcess(path string, args, env []string) error {
+	// #nosec G204 -- runs the server command configured by the user
+	cmd := exec.Command(path, args[1:]...)
+	cmd.Env = env
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		os.Exit(exitErr.ExitCode())
+	}
+	return err
+}
@@ -0,0 +1,15 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+//go:build unix
+
+package command
+
+import "syscall"
+
+// execProcess replaces the current process, so Claude Code talks to the MCP
+// server directly over the inherited stdio and signals reach it unchanged.
+func execProcess(path string, args, env []string) error {
+	// #nosec G204 -- runs the server command configured by the user
+	return syscall.Exec(path, args, env)
+}
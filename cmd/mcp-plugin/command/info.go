@@ -4,47 +4,103 @@
 package command
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/infrastructure/npm"
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/infrastructure/pypi"
 	"github.com/spf13/cobra"
 )
 
 func newInfoCmd() *cobra.Command {
+	var (
+		npmf    npmFlags
+		usePyPI bool
+	)
+
 	cmd := &cobra.Command{
 		Use:   "info <package>",
 		Short: "Show information about an MCP package",
 		Long: `Display detailed information about an npm package.
 
 This fetches package metadata from npm registry including
-version, description, repository, and usage hints.
+version, description, repository, and usage hints. With --pypi the
+package is looked up on PyPI instead, for servers run with uvx.
 
 Examples:
   # Get info about context7 MCP server
   mcp-plugin info @upstash/context7-mcp
 
   # Get info about playwright MCP server
-  mcp-plugin info @playwright/mcp`,
+  mcp-plugin info @playwright/mcp
+
+  # Get info about a Python MCP server
+  mcp-plugin info mcp-server-git --pypi`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runInfo(args[0])
+			if usePyPI {
+				return runPyPIInfo(cmd.Context(), args[0])
+			}
+			return runInfo(cmd.Context(), args[0], npmf)
 		},
 	}
 
+	addNpmFlags(cmd, &npmf)
+	cmd.Flags().BoolVar(&usePyPI, "pypi", false, "Look the package up on PyPI (uvx servers)")
+
 	return cmd
 }
 
-func runInfo(packageName string) error {
-	client := npm.NewClient()
+// packageInfoView is the structured form of `info`.
+type packageInfoView struct {
+	Name        string      `json:"name"`
+	Version     string      `json:"version"`
+	License     string      `json:"license,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Author      *npm.Author `json:"author,omitempty"`
+	NPM         string      `json:"npm_url"`
+	Homepage    string      `json:"homepage,omitempty"`
+	Repository  string      `json:"repository,omitempty"`
+	Versions    int         `json:"versions"`
+}
+
+func runInfo(ctx context.Context, packageName string, npmf npmFlags) error {
+	client := newNpmClient(npmf)
 
-	fmt.Printf("Fetching information for '%s'...\n\n", packageName)
+	if textOutput() {
+		fmt.Printf("Fetching information for '%s'...\n\n", packageName)
+	}
 
-	pkg, err := client.GetPackage(packageName)
+	pkg, err := client.GetPackage(ctx, packageName)
 	if err != nil {
 		return fmt.Errorf("failed to get package info: %w", err)
 	}
 
+	view := packageInfoView{
+		Name:        pkg.Name,
+		Version:     pkg.LatestVersion(),
+		License:     pkg.License,
+		Description: pkg.Description,
+		Author:      pkg.Author,
+		NPM:         "https://www.npmjs.com/package/" + pkg.Name,
+		Homepage:    pkg.Homepage,
+		Repository:  repositoryURL(pkg.Repository.URL),
+		Versions:    len(pkg.Versions),
+	}
+	if handled, err := render("PackageInfo", view, func() table {
+		return table{
+			header: []string{"NAME", "VERSION", "LICENSE", "VERSIONS", "REPOSITORY"},
+			rows: [][]string{{
+				view.Name, view.Version, orDash(view.License), strconv.Itoa(view.Versions), orDash(view.Repository),
+			}},
+		}
+	}); handled {
+		return err
+	}
+
 	// Package name
 	fmt.Printf("Package: %s\n", pkg.Name)
 	fmt.Printf("Version: %s\n", pkg.LatestVersion())
@@ -73,11 +129,8 @@ func runInfo(packageName string) error {
 	if pkg.Homepage != "" {
 		fmt.Printf("  homepage: %s\n", pkg.Homepage)
 	}
-	if pkg.Repository.URL != "" {
-		repoURL := pkg.Repository.URL
-		repoURL = strings.TrimPrefix(repoURL, "git+")
-		repoURL = strings.TrimSuffix(repoURL, ".git")
-		fmt.Printf("  repository: %s\n", repoURL)
+	if view.Repository != "" {
+		fmt.Printf("  repository: %s\n", view.Repository)
 	}
 
 	// Usage hint for MCP packages
@@ -91,3 +144,92 @@ func runInfo(packageName string) error {
 
 	return nil
 }
+
+// repositoryURL turns an npm repository URL into a browsable one.
+func repositoryURL(raw string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(raw, "git+"), ".git")
+}
+
+// pypiInfoView is the structured form of `info --pypi`.
+type pypiInfoView struct {
+	Name           string            `json:"name"`
+	Version        string            `json:"version"`
+	License        string            `json:"license,omitempty"`
+	Summary        string            `json:"summary,omitempty"`
+	Author         string            `json:"author,omitempty"`
+	RequiresPython string            `json:"requires_python,omitempty"`
+	PyPI           string            `json:"pypi_url"`
+	ProjectURLs    map[string]string `json:"project_urls,omitempty"`
+	Releases       []string          `json:"releases"`
+}
+
+func runPyPIInfo(ctx context.Context, packageName string) error {
+	if textOutput() {
+		fmt.Printf("Fetching information for '%s' from PyPI...\n\n", packageName)
+	}
+
+	project, err := pypi.NewClient().GetProject(ctx, packageName)
+	if err != nil {
+		return fmt.Errorf("failed to get package info: %w", err)
+	}
+
+	info := project.Info
+	view := pypiInfoView{
+		Name:           info.Name,
+		Version:        project.LatestVersion(),
+		License:        firstLine(info.License),
+		Summary:        info.Summary,
+		Author:         info.Author,
+		RequiresPython: info.RequiresPython,
+		PyPI:           project.URL(),
+		ProjectURLs:    info.ProjectURLs,
+		Releases:       project.Versions(),
+	}
+	if view.Author == "" {
+		view.Author = info.AuthorEmail
+	}
+	if handled, err := render("PyPIPackageInfo", view, func() table {
+		return table{
+			header: []string{"NAME", "VERSION", "LICENSE", "RELEASES", "REQUIRES-PYTHON"},
+			rows: [][]string{{
+				view.Name, view.Version, orDash(view.License), strconv.Itoa(len(view.Releases)), orDash(view.RequiresPython),
+			}},
+		}
+	}); handled {
+		return err
+	}
+
+	fmt.Printf("Package: %s\n", view.Name)
+	fmt.Printf("Version: %s\n", view.Version)
+	if view.License != "" {
+		fmt.Printf("License: %s\n", view.License)
+	}
+	if view.RequiresPython != "" {
+		fmt.Printf("Requires Python: %s\n", view.RequiresPython)
+	}
+
+	if view.Summary != "" {
+		fmt.Printf("\nDescription:\n  %s\n", view.Summary)
+	}
+	if view.Author != "" {
+		fmt.Printf("\nAuthor: %s\n", view.Author)
+	}
+
+	fmt.Println("\nLinks:")
+	fmt.Printf("  pypi: %s\n", view.PyPI)
+	labels := make([]string, 0, len(view.ProjectURLs))
+	for label := range view.ProjectURLs {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	for _, label := range labels {
+		fmt.Printf("  %s: %s\n", strings.ToLower(label), view.ProjectURLs[label])
+	}
+
+	fmt.Println("\nUsage with Claude Code:")
+	fmt.Printf("  mcp-plugin install <name> --uvx %s\n", pypi.Normalize(view.Name))
+
+	fmt.Printf("\nReleases: %d available\n", len(view.Releases))
+
+	return nil
+}
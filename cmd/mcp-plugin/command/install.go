@@ -4,10 +4,15 @@
 package command
 
 import (
+	"cmp"
 	"fmt"
+	"os"
+	"sort"
 	"strings"
 
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/catalog"
 	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/config"
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/lockfile"
 	"github.com/spf13/cobra"
 )
 
@@ -17,6 +22,15 @@ var (
 	installUVX     bool
 	installCommand string
 	installArgs    []string
+	installEnv     []string
+	installEnvFile string
+	installHeaders []string
+	installProject bool
+	installScope   string
+	installPin     bool
+	installVerify  verifyFlags
+	installCatalog string
+	installParams  []string
 )
 
 func newInstallCmd() *cobra.Command {
@@ -25,7 +39,12 @@ func newInstallCmd() *cobra.Command {
 		Short: "Install an MCP server",
 		Long: `Install an MCP server to Claude Code configuration.
 
-By default, installs an npx-based MCP server:
+A name from the catalog alone installs that server, asking for the values it
+needs that were not given with --env, --header or --param (see
+'mcp-plugin catalog --help'):
+  mcp-plugin install github
+
+Otherwise, installs an npx-based MCP server:
   mcp-plugin install myserver @package/mcp-server
 
 For HTTP-based servers:
@@ -37,7 +56,37 @@ For uvx-based (Python) servers:
 For custom command servers:
   mcp-plugin install myserver --command node --args server.js,--port,8080
 
+--pin resolves the package (and any tag or range it names) against the
+registry and writes the exact version, e.g. 'npx -y @upstash/context7-mcp@1.0.14',
+so the server does not change until 'mcp-plugin update'.
+
+npx and uvx packages are checked for supply-chain risks first, as by
+'mcp-plugin verify': recent publication (--min-age-days), maintainer changes,
+a missing repository link, install scripts, deprecation and names close to
+well-known MCP packages. Findings are printed; --strict refuses to install.
+Servers that break the system or repository policy are refused (see
+'mcp-plugin policy --help').
+
+Use --scope to choose where the server is written: user (default, all
+projects), project (the nearest .mcp.json, shared via git; also --project) or
+local (current project only, private).
+
+Environment variables for command servers can be set with --env (repeatable)
+or loaded from a dotenv file with --env-file. --env overrides --env-file.
+HTTP servers take request headers with --header (repeatable).
+
+Values can refer to secrets instead of containing them: ${env:NAME},
+${file:PATH} or ${keyring:NAME} (see 'mcp-plugin secret --help'). Quote
+them so the shell does not expand them.
+
 Examples:
+  # Install a server from the catalog
+  mcp-plugin install filesystem --param ROOT=$HOME/projects
+
+  # Install a catalog entry under another name, without prompts
+  mcp-plugin install github-work --catalog github \
+    --header 'Authorization: Bearer ${keyring:github-work}'
+
   # Install an npx MCP server
   mcp-plugin install context7 @upstash/context7-mcp
 
@@ -45,7 +94,23 @@ Examples:
   mcp-plugin install myapi --http --url https://api.example.com/mcp
 
   # Install a uvx (Python) MCP server
-  mcp-plugin install serena --uvx serena-mcp`,
+  mcp-plugin install serena --uvx serena-mcp
+
+  # Install at the current latest version, pinned
+  mcp-plugin install context7 @upstash/context7-mcp --pin
+
+  # Refuse the package if any supply-chain check flags it
+  mcp-plugin install context7 @upstash/context7-mcp --pin --strict
+
+  # Share a server with the team through the repository's .mcp.json
+  mcp-plugin install playwright @playwright/mcp --project
+
+  # Install a server that needs a token, without writing the token to disk
+  mcp-plugin install github @modelcontextprotocol/server-github --env 'GITHUB_TOKEN=${keyring:github}'
+
+  # Authenticate an HTTP server from an environment variable
+  mcp-plugin install myapi --http --url https://api.example.com/mcp \
+    --header 'Authorization: Bearer ${env:MYAPI_TOKEN}'`,
 		Args: cobra.RangeArgs(1, 2),
 		RunE: runInstall,
 	}
@@ -55,6 +120,15 @@ Examples:
 	cmd.Flags().BoolVar(&installUVX, "uvx", false, "Install as uvx (Python) server")
 	cmd.Flags().StringVar(&installCommand, "command", "", "Custom command (e.g., node, python)")
 	cmd.Flags().StringSliceVar(&installArgs, "args", nil, "Custom command arguments")
+	cmd.Flags().StringArrayVar(&installEnv, "env", nil, "Environment variable KEY=VALUE (repeatable)")
+	cmd.Flags().StringVar(&installEnvFile, "env-file", "", "Load environment variables from a dotenv file")
+	cmd.Flags().StringArrayVar(&installHeaders, "header", nil, "HTTP header 'Name: value' (repeatable)")
+	cmd.Flags().StringVar(&installCatalog, "catalog", "", "Install this catalog entry under <name>")
+	cmd.Flags().StringArrayVar(&installParams, "param", nil, "Catalog entry parameter NAME=VALUE (repeatable)")
+	cmd.Flags().BoolVar(&installPin, "pin", false, "Write the exact version the package resolves to (npx and uvx)")
+	addVerifyFlags(cmd, &installVerify)
+	addScopeFlag(cmd, &installScope)
+	addProjectFlag(cmd, &installProject)
 
 	return cmd
 }
@@ -62,7 +136,14 @@ Examples:
 func runInstall(cmd *cobra.Command, args []string) error {
 	name := args[0]
 
-	writer := config.NewWriter()
+	scope, err := resolveScopeFlags(cmd, installScope, installProject)
+	if err != nil {
+		return err
+	}
+	writer, err := scopedWriter(scope)
+	if err != nil {
+		return err
+	}
 
 	// Check if server already exists
 	exists, err := writer.MCPServerExists(name)
@@ -70,12 +151,29 @@ func runInstall(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to check server: %w", err)
 	}
 	if exists {
-		return fmt.Errorf("MCP server '%s' already exists. Use 'remove' first to reinstall", name)
+		return fmt.Errorf("MCP server '%s' already exists in %s scope. Use 'remove' first to reinstall", name, writer.Scope())
+	}
+
+	env, err := loadInstallEnv()
+	if err != nil {
+		return err
+	}
+
+	template, err := installTemplate(args)
+	if err != nil {
+		return err
 	}
 
 	var entry config.MCPServerEntry
 
 	switch {
+	case template != nil:
+		entry, err = expandTemplate(template, env, installHeaders, installParams)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Installing MCP server '%s' from the catalog (%s)...\n", name, template.Name)
+
 	case installHTTP:
 		// HTTP-based server
 		if installURL == "" {
@@ -117,7 +215,7 @@ func runInstall(cmd *cobra.Command, args []string) error {
 		if len(args) > 1 {
 			pkg = args[1]
 		} else {
-			return fmt.Errorf("package name required for npx install (e.g., mcp-plugin install %s @package/name)", name)
+			return fmt.Errorf("package name required for npx install (e.g., mcp-plugin install %s @package/name), or a catalog entry name (see 'mcp-plugin catalog list')", name)
 		}
 
 		// Add -y flag if not already present
@@ -130,18 +228,95 @@ func runInstall(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Installing npx MCP server '%s' (package: %s)...\n", name, pkg)
 	}
 
+	if installPin {
+		version, err := pinInstallEntry(cmd.Context(), &entry)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Pinned to version %s.\n", version)
+	}
+
+	if len(env) > 0 && template == nil {
+		if entry.Command == "" {
+			return fmt.Errorf("--env and --env-file apply only to command servers")
+		}
+		entry.Env = env
+	}
+
+	if len(installHeaders) > 0 && template == nil {
+		if entry.URL == "" {
+			return fmt.Errorf("--header applies only to HTTP servers")
+		}
+		headers, err := parseHeaders(installHeaders)
+		if err != nil {
+			return err
+		}
+		entry.Headers = headers
+	}
+
+	entry, err = prepareSecrets(entry)
+	if err != nil {
+		return err
+	}
+
+	policies, err := loadPolicies()
+	if err != nil {
+		return err
+	}
+	if err := checkPolicy(policies, name, writer.Scope(), entry); err != nil {
+		return err
+	}
+
+	if ref, ok := registryPackage(entry.Command, entry.Args); ok {
+		if err := verifyInstall(cmd.Context(), ref, installVerify); err != nil {
+			return err
+		}
+	}
+
 	// Add the server
 	if err := writer.AddMCPServer(name, entry); err != nil {
 		return fmt.Errorf("failed to install server: %w", err)
 	}
 
-	fmt.Printf("MCP server '%s' has been installed.\n", name)
+	fmt.Printf("MCP server '%s' has been installed (%s scope: %s).\n", name, writer.Scope(), writer.ServersPath())
 	printServerConfig(name, entry)
+	reportLockSync(syncLockfile(cmd.Context(), lockfile.Key(string(writer.Scope()), name)))
 	fmt.Println("\nNote: Restart Claude Code for the new server to be available.")
 
 	return nil
 }
 
+// installTemplate returns the catalog entry to install: the one named by
+// --catalog, or the one called <name> when neither a package nor a
+// transport is given.
+func installTemplate(args []string) (*catalog.Template, error) {
+	explicit := len(args) > 1 || installHTTP || installUVX || installCommand != ""
+	switch {
+	case installCatalog != "" && explicit:
+		return nil, fmt.Errorf("--catalog cannot be combined with a package, --http, --uvx or --command")
+	case explicit && len(installParams) > 0:
+		return nil, fmt.Errorf("--param applies only to catalog installs")
+	case explicit:
+		return nil, nil
+	}
+
+	cat, err := loadCatalog()
+	if err != nil {
+		return nil, err
+	}
+	key := cmp.Or(installCatalog, args[0])
+	template, ok := cat.Get(key)
+	if !ok && (installCatalog != "" || len(installParams) > 0) {
+		return nil, fmt.Errorf("no catalog entry '%s' (see 'mcp-plugin catalog list')", key)
+	}
+	if ok && template.Replaces != "" {
+		if err := confirmReplacement(template, installCatalog != ""); err != nil {
+			return nil, err
+		}
+	}
+	return template, nil
+}
+
 func printServerConfig(name string, entry config.MCPServerEntry) {
 	fmt.Printf("\nConfiguration:\n")
 	fmt.Printf("  Name: %s\n", name)
@@ -157,4 +332,76 @@ func printServerConfig(name string, entry config.MCPServerEntry) {
 	if entry.URL != "" {
 		fmt.Printf("  URL: %s\n", entry.URL)
 	}
+	names := make([]string, 0, len(entry.Headers))
+	for k := range entry.Headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		fmt.Printf("  Header: %s: %s\n", k, maskSensitiveHeader(k, entry.Headers[k]))
+	}
+	printEnv(entry.Env, "  ")
+}
+
+// parseHeaders parses "Name: value" header flags.
+func parseHeaders(values []string) (map[string]string, error) {
+	headers := make(map[string]string, len(values))
+	for _, v := range values {
+		name, value, ok := strings.Cut(v, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid header %q: expected 'Name: value'", v)
+		}
+		headers[name] = strings.TrimSpace(value)
+	}
+	return headers, nil
+}
+
+// loadInstallEnv merges --env-file and --env values; --env wins.
+func loadInstallEnv() (map[string]string, error) {
+	env := make(map[string]string)
+
+	if installEnvFile != "" {
+		// #nosec G304 -- installEnvFile is an intentional user-provided CLI path
+		f, err := os.Open(installEnvFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read env file: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+
+		fileEnv, err := config.ParseEnvFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse env file %s: %w", installEnvFile, err)
+		}
+		for k, v := range fileEnv {
+			env[k] = v
+		}
+	}
+
+	for _, kv := range installEnv {
+		key, value, err := config.ParseEnvAssignment(kv)
+		if err != nil {
+			return nil, err
+		}
+		env[key] = value
+	}
+
+	return env, nil
+}
+
+// printEnv prints environment variables sorted by name with masked values.
+func printEnv(env map[string]string, indent string) {
+	if len(env) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	fmt.Printf("%sEnv:\n", indent)
+	for _, k := range keys {
+		fmt.Printf("%s  %s=%s\n", indent, k, maskSecret(env[k]))
+	}
 }
@@ -5,61 +5,313 @@ package command
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/config"
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/probe"
 	"github.com/spf13/cobra"
 )
 
-var listEnabledOnly bool
+var (
+	listEnabledOnly bool
+	listScope       string
+	listPlugins     bool
+	listAll         bool
+)
 
 func newListCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "list",
-		Short: "List MCP servers",
-		Long:  `List all configured MCP servers from Claude Code configuration.`,
-		RunE:  runList,
+		Short: "List MCP servers and plugins",
+		Long: `List all configured MCP servers from Claude Code configuration.
+
+Servers are collected from every scope: user (~/.claude.json), local (the
+current project in ~/.claude.json), project (.mcp.json at the repository
+root) and plugin (installed Claude Code plugins).
+
+Servers in user, local and project scope are enabled unless turned off for
+the current project, as /mcp in Claude Code does: user and local servers named
+in the project's disabledMcpServers in ~/.claude.json, and .mcp.json servers
+named in disabledMcpjsonServers there or in the user's or project's Claude
+settings. Claude Code still asks before first starting a .mcp.json server; a
+server awaiting that approval is listed as enabled.
+
+A plugin server is enabled when its plugin is: --plugins lists plugins by the
+name@marketplace ID 'enable' and 'disable' take, with the servers each one
+provides. A plugin not named in settings.json is disabled.
+
+Tool counts come from the cache 'mcp-plugin server tools' keeps; servers
+never queried, or changed since, show none.
+
+Examples:
+  # List all servers
+  mcp-plugin list
+
+  # List only servers checked into the current repository
+  mcp-plugin list --scope project
+
+  # List plugins and the servers they provide
+  mcp-plugin list --plugins
+
+  # List servers and plugins together
+  mcp-plugin list --all`,
+		Args: cobra.NoArgs,
+		RunE: runList,
 	}
 
-	cmd.Flags().BoolVar(&listEnabledOnly, "enabled", false, "Show only enabled servers")
+	cmd.Flags().BoolVar(&listEnabledOnly, "enabled", false, "Show only enabled servers and plugins")
+	cmd.Flags().StringVarP(&listScope, "scope", "s", "", "Show only servers from this scope (user, project, local, plugin)")
+	cmd.Flags().BoolVar(&listPlugins, "plugins", false, "List plugins instead of servers")
+	cmd.Flags().BoolVar(&listAll, "all", false, "List servers and plugins")
+	cmd.MarkFlagsMutuallyExclusive("plugins", "all")
+	cmd.MarkFlagsMutuallyExclusive("plugins", "scope")
 
 	return cmd
 }
 
+// listAllView is the structured form of `list --all`.
+type listAllView struct {
+	Servers []serverListItem `json:"servers"`
+	Plugins []config.Plugin  `json:"plugins"`
+}
+
 func runList(cmd *cobra.Command, args []string) error {
-	reader := config.NewReader()
+	reader := newReader()
 
-	servers, err := reader.ListMCPServers()
-	if err != nil {
-		return fmt.Errorf("failed to list MCP servers: %w", err)
+	var items []serverListItem
+	if !listPlugins {
+		servers, err := reader.ListMCPServers()
+		if err != nil {
+			return fmt.Errorf("failed to list MCP servers: %w", err)
+		}
+		servers, err = filterByScope(servers, listScope)
+		if err != nil {
+			return err
+		}
+		if listEnabledOnly {
+			servers = enabledServers(servers)
+		}
+		items = serverListItems(servers)
+	}
+
+	var plugins []config.Plugin
+	if listPlugins || listAll {
+		var err error
+		plugins, err = reader.ListPlugins()
+		if err != nil {
+			return fmt.Errorf("failed to list plugins: %w", err)
+		}
+		if listEnabledOnly {
+			plugins = enabledPlugins(plugins)
+		}
+	}
+
+	switch {
+	case listPlugins:
+		if handled, err := render("PluginList", plugins, func() table {
+			return pluginTable(plugins)
+		}); handled {
+			return err
+		}
+		printPluginList(plugins)
+	case listAll:
+		view := listAllView{Servers: items, Plugins: plugins}
+		if handled, err := render("ServerPluginList", view, func() table {
+			return listAllTable(view)
+		}); handled {
+			return err
+		}
+		printServerList(items)
+		if len(items) == 0 {
+			fmt.Println()
+		}
+		printPluginList(plugins)
+	default:
+		if handled, err := render("ServerList", items, func() table {
+			return serverTable(items)
+		}); handled {
+			return err
+		}
+		printServerList(items)
 	}
 
-	if len(servers) == 0 {
+	return nil
+}
+
+func printServerList(items []serverListItem) {
+	if len(items) == 0 {
 		fmt.Println("No MCP servers found.")
-		return nil
+		return
 	}
 
-	fmt.Printf("Found %d MCP server(s):\n\n", len(servers))
+	fmt.Printf("Found %d MCP server(s):\n\n", len(items))
 
-	for _, server := range servers {
-		if listEnabledOnly && !server.Enabled {
-			continue
+	for _, item := range items {
+		fmt.Printf("  %s (%s)\n", item.Name, serverStatus(item.MCPServer))
+		fmt.Printf("    Type: %s\n", item.Type)
+		fmt.Printf("    Scope: %s\n", item.Source)
+		if item.Plugin != "" {
+			fmt.Printf("    Plugin: %s\n", item.Plugin)
+		}
+		if item.URL != "" {
+			fmt.Printf("    URL: %s\n", item.URL)
+		}
+		if item.Command != "" {
+			fmt.Printf("    Command: %s %v\n", item.Command, item.Args)
 		}
+		if item.Tools != nil {
+			fmt.Printf("    Tools: %d (as of %s)\n", *item.Tools, item.ToolsCheckedAt.Local().Format(time.DateTime))
+		}
+		fmt.Println()
+	}
+}
+
+func printPluginList(plugins []config.Plugin) {
+	if len(plugins) == 0 {
+		fmt.Println("No plugins found.")
+		return
+	}
+
+	fmt.Printf("Found %d plugin(s):\n\n", len(plugins))
 
-		status := statusDisabled
-		if server.Enabled {
-			status = statusEnabled
+	for _, p := range plugins {
+		fmt.Printf("  %s (%s)\n", p.ID, pluginStatus(p))
+		switch {
+		case !p.Installed:
+			fmt.Println("    Not in the plugin cache")
+		case len(p.Servers) == 0:
+			fmt.Println("    Servers: none")
+		default:
+			fmt.Printf("    Servers: %s\n", strings.Join(p.Servers, ", "))
 		}
+		fmt.Println()
+	}
+}
 
-		fmt.Printf("  %s (%s)\n", server.Name, status)
-		fmt.Printf("    Type: %s\n", server.Type)
-		if server.URL != "" {
-			fmt.Printf("    URL: %s\n", server.URL)
+func enabledServers(servers []config.MCPServer) []config.MCPServer {
+	var enabled []config.MCPServer
+	for _, s := range servers {
+		if s.Enabled {
+			enabled = append(enabled, s)
 		}
-		if server.Command != "" {
-			fmt.Printf("    Command: %s %v\n", server.Command, server.Args)
+	}
+	return enabled
+}
+
+func enabledPlugins(plugins []config.Plugin) []config.Plugin {
+	var enabled []config.Plugin
+	for _, p := range plugins {
+		if p.Enabled {
+			enabled = append(enabled, p)
 		}
-		fmt.Println()
 	}
+	return enabled
+}
+
+func serverStatus(server config.MCPServer) string {
+	if server.Enabled {
+		return statusEnabled
+	}
+	return statusDisabled
+}
 
-	return nil
+func pluginStatus(plugin config.Plugin) string {
+	if plugin.Enabled {
+		return statusEnabled
+	}
+	return statusDisabled
+}
+
+// serverTarget summarizes what a server runs or connects to.
+func serverTarget(server config.MCPServer) string {
+	if server.URL != "" {
+		return server.URL
+	}
+	return strings.TrimSpace(server.Command + " " + strings.Join(server.Args, " "))
+}
+
+// serverListItem is a listed server with its cached tool count, if any.
+type serverListItem struct {
+	config.MCPServer
+	Tools          *int       `json:"tools,omitempty"`
+	ToolsCheckedAt *time.Time `json:"tools_checked_at,omitempty"`
+}
+
+// serverListItems masks servers and attaches tool counts from the cache. A
+// cache that cannot be read only costs the counts.
+func serverListItems(servers []config.MCPServer) []serverListItem {
+	cache, err := probe.LoadCache(toolCachePath())
+	if err != nil {
+		warnf("%v", err)
+	}
+
+	items := make([]serverListItem, 0, len(servers))
+	for _, s := range servers {
+		item := serverListItem{MCPServer: maskServer(s)}
+		if cache != nil {
+			if entry, ok := cache.Lookup(s); ok {
+				tools, checked := len(entry.Inventory.Tools), entry.CheckedAt
+				item.Tools, item.ToolsCheckedAt = &tools, &checked
+			}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func serverTable(items []serverListItem) table {
+	t := table{header: []string{"NAME", "STATUS", "TYPE", "SCOPE", "TOOLS", "TARGET"}}
+	for _, item := range items {
+		tools := "-"
+		if item.Tools != nil {
+			tools = strconv.Itoa(*item.Tools)
+		}
+		s := item.MCPServer
+		t.rows = append(t.rows, []string{s.Name, serverStatus(s), s.Type, s.Source, tools, orDash(serverTarget(s))})
+	}
+	return t
+}
+
+func pluginTable(plugins []config.Plugin) table {
+	t := table{header: []string{"ID", "STATUS", "INSTALLED", "SERVERS"}}
+	for _, p := range plugins {
+		t.rows = append(t.rows, []string{p.ID, pluginStatus(p), strconv.FormatBool(p.Installed), orDash(strings.Join(p.Servers, ","))})
+	}
+	return t
+}
+
+// listAllTable lists servers and plugins in one table, plugins after
+// servers.
+func listAllTable(view listAllView) table {
+	t := table{header: []string{"NAME", "KIND", "STATUS", "SCOPE", "TARGET"}}
+	for _, item := range view.Servers {
+		s := item.MCPServer
+		t.rows = append(t.rows, []string{s.Name, "server", serverStatus(s), s.Source, orDash(serverTarget(s))})
+	}
+	for _, p := range view.Plugins {
+		t.rows = append(t.rows, []string{p.ID, "plugin", pluginStatus(p), "-", orDash(strings.Join(p.Servers, ","))})
+	}
+	return t
+}
+
+// maskServer returns a copy of server with credential values masked, for
+// output that may end up in logs or CI artifacts.
+func maskServer(server config.MCPServer) config.MCPServer {
+	if len(server.Headers) > 0 {
+		headers := make(map[string]string, len(server.Headers))
+		for k, v := range server.Headers {
+			headers[k] = maskSensitiveHeader(k, v)
+		}
+		server.Headers = headers
+	}
+	if len(server.Env) > 0 {
+		env := make(map[string]string, len(server.Env))
+		for k, v := range server.Env {
+			env[k] = maskSecret(v)
+		}
+		server.Env = env
+	}
+	return server
 }
@@ -0,0 +1,349 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/config"
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/lockfile"
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/versions"
+	"github.com/spf13/cobra"
+)
+
+// lockOptions are the flags of `lock` and `lock verify`.
+type lockOptions struct {
+	path   string
+	scopes []string
+	npm    npmFlags
+	// only limits a refresh to these servers (lockfile.Key); the rest of
+	// the lock is kept as it was.
+	only  []string
+	quiet bool // set when refreshing after another command
+}
+
+func newLockCmd() *cobra.Command {
+	var opts lockOptions
+
+	cmd := &cobra.Command{
+		Use:   "lock",
+		Short: "Record exact versions and integrity of npx and uvx servers",
+		Long: `Write mcp-plugin.lock, recording for every npx and uvx server its package,
+the exact version its spec resolves to, the registry's integrity hash of that
+version and the scope it is configured in.
+
+The lock is written next to the project's .mcp.json so it can be committed
+with it. It covers the project scope, the servers the team shares; --scope
+adds the user or local scope, which exist only on your machine. Later runs
+keep the scopes of the existing lock. Once the lock exists, install, remove,
+update and pin refresh it for servers in the scopes it covers.
+
+npm entries use the tarball's dist.integrity (or its SHA-1 shasum for old
+packages). PyPI has no single tarball, so uvx entries record the SHA-256 of
+the file uv prefers: the pure-Python wheel, else the source distribution.
+
+Examples:
+  # Create or refresh the lock
+  mcp-plugin lock
+
+  # Also lock the servers only you have (a lock you do not commit)
+  mcp-plugin lock --scope project,user,local
+
+  # Check the configuration against the lock (e.g. in CI)
+  mcp-plugin lock verify`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLock(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.path, "lockfile", "", "Lockfile path (default: mcp-plugin.lock next to the project's .mcp.json)")
+	cmd.Flags().StringSliceVarP(&opts.scopes, "scope", "s", nil, "Scopes to lock (default: project)")
+	addNpmFlags(cmd, &opts.npm)
+
+	cmd.AddCommand(newLockVerifyCmd())
+	return cmd
+}
+
+func newLockVerifyCmd() *cobra.Command {
+	var path string
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Report drift between the lock and the configuration",
+		Long: `Compare the configured npx and uvx servers with mcp-plugin.lock.
+
+Each server in a scope the lock covers is reported as:
+  ok        pinned to the locked version
+  floating  not pinned (a tag, range or nothing); may not run the locked version
+  changed   pinned to another version
+  package   runs another package than the lock records
+  unlocked  configured but not in the lock
+  missing   in the lock but no longer configured
+
+verify exits non-zero when anything other than ok or floating is found.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLockVerify(path)
+		},
+	}
+
+	cmd.Flags().StringVar(&path, "lockfile", "", "Lockfile path (default: mcp-plugin.lock next to the project's .mcp.json)")
+	return cmd
+}
+
+// defaultLockPath puts the lock beside the project's .mcp.json.
+func defaultLockPath() string {
+	return filepath.Join(projectDir(), lockfile.FileName)
+}
+
+func lockPath(flag string) string {
+	if flag != "" {
+		return flag
+	}
+	return defaultLockPath()
+}
+
+func runLock(ctx context.Context, opts lockOptions) error {
+	path := lockPath(opts.path)
+
+	previous, err := lockfile.Load(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	scopes := opts.scopes
+	switch {
+	case len(scopes) > 0:
+		for _, s := range scopes {
+			if _, err := config.ParseScope(s); err != nil {
+				return err
+			}
+		}
+	case previous != nil:
+		scopes = previous.Scopes
+	default:
+		// User and local servers exist on one machine only; a committed
+		// lock recording them shows drift for everyone else.
+		scopes = []string{string(config.ScopeProject)}
+	}
+	if previous == nil {
+		previous = lockfile.New(path, scopes)
+	}
+
+	live, err := liveLockServers()
+	if err != nil {
+		return err
+	}
+
+	lock := lockfile.New(path, scopes)
+	if opts.only != nil {
+		// A partial refresh keeps every other entry, including those of
+		// servers no longer configured, so their drift still shows.
+		for key, old := range previous.Servers {
+			if !slices.Contains(opts.only, key) {
+				lock.Servers[key] = old
+			}
+		}
+	}
+
+	sources := newRegistries(opts.npm)
+	var failed int
+	for _, s := range live {
+		if !lock.Covers(s.Scope) {
+			continue
+		}
+		key := lockfile.Key(s.Scope, s.Name)
+		if opts.only != nil && !slices.Contains(opts.only, key) {
+			continue
+		}
+		// An exact pin that is already locked cannot resolve differently.
+		if old, ok := previous.Servers[key]; ok && s.Pinned && old.Version == s.Spec && old.Package == s.Package && old.Registry == s.Registry {
+			lock.Servers[key] = old
+			continue
+		}
+		entry, err := lockEntry(ctx, sources, s)
+		if err != nil {
+			warnf("cannot lock %s (%s): %v", s.Name, s.Scope, err)
+			failed++
+			continue
+		}
+		lock.Servers[key] = entry
+		if !opts.quiet {
+			fmt.Printf("🔒 %s (%s): %s@%s\n", s.Name, s.Scope, entry.Package, entry.Version)
+		}
+	}
+
+	if err := lock.Save(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if !opts.quiet {
+		fmt.Printf("\nLocked %d server(s) in %s.\n", len(lock.Servers), path)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d server(s) could not be locked", failed)
+	}
+	return nil
+}
+
+func runLockVerify(flag string) error {
+	path := lockPath(flag)
+	lock, err := lockfile.Load(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("no lockfile at %s; run 'mcp-plugin lock' first", path)
+	}
+	if err != nil {
+		return err
+	}
+
+	live, err := liveLockServers()
+	if err != nil {
+		return err
+	}
+	findings := lock.Verify(live)
+
+	var drift int
+	for _, f := range findings {
+		if f.Drift() {
+			drift++
+		}
+	}
+
+	if handled, err := render("LockVerification", findings, func() table {
+		t := table{header: []string{"NAME", "SCOPE", "STATUS", "LOCKED", "CONFIGURED", "MESSAGE"}}
+		for _, f := range findings {
+			t.rows = append(t.rows, []string{f.Name, f.Scope, string(f.Status), orDash(f.Locked), orDash(f.Live), f.Message})
+		}
+		return t
+	}); handled {
+		if err == nil && drift > 0 {
+			err = fmt.Errorf("%d server(s) drifted from %s", drift, path)
+		}
+		return err
+	}
+
+	if len(findings) == 0 {
+		fmt.Printf("No npx or uvx servers in the scopes %s covers.\n", path)
+		return nil
+	}
+	for _, f := range findings {
+		icon := "✅"
+		switch {
+		case f.Drift():
+			icon = "❌"
+		case f.Status == lockfile.StatusFloating:
+			icon = "⚠️ "
+		}
+		fmt.Printf("%s %s (%s): %s\n", icon, f.Name, f.Scope, f.Message)
+	}
+	fmt.Println()
+	if drift > 0 {
+		return fmt.Errorf("%d server(s) drifted from %s", drift, path)
+	}
+	fmt.Println("Configuration matches the lock.")
+	return nil
+}
+
+// liveLockServers lists the configured servers a lock can record: npx and
+// uvx servers whose package comes from a registry.
+func liveLockServers() ([]lockfile.Live, error) {
+	servers, err := newReader().ListMCPServers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list servers: %w", err)
+	}
+	var live []lockfile.Live
+	for _, server := range servers {
+		if s, ok := lockTarget(server); ok {
+			live = append(live, s)
+		}
+	}
+	return live, nil
+}
+
+func lockTarget(server config.MCPServer) (lockfile.Live, bool) {
+	ref, ok := registryPackage(server.Command, server.Args)
+	return lockfile.Live{
+		Name: server.Name, Scope: server.Source,
+		Registry: ref.registry, Package: ref.name, Spec: ref.spec, Pinned: ref.pinned,
+	}, ok
+}
+
+// lockEntry looks up the version and integrity a server's spec resolves to.
+func lockEntry(ctx context.Context, r registries, s lockfile.Live) (lockfile.Entry, error) {
+	entry := lockfile.Entry{Name: s.Name, Scope: s.Scope, Registry: s.Registry, Package: s.Package}
+
+	if s.Registry == "npm" {
+		pkg, err := r.npm.GetPackageVersions(ctx, s.Package)
+		if err != nil {
+			return entry, err
+		}
+		version, err := versions.Resolve(s.Spec, pkg.VersionList(), pkg.DistTags)
+		if err != nil {
+			return entry, err
+		}
+		dist := pkg.Versions[version].Dist
+		entry.Version, entry.Resolved, entry.Integrity = version, dist.Tarball, dist.SRI()
+		return entry, nil
+	}
+
+	if r.offline {
+		return entry, fmt.Errorf("PyPI is not available offline")
+	}
+	project, err := r.pypi.GetProject(ctx, s.Package)
+	if err != nil {
+		return entry, err
+	}
+	version, err := versions.Resolve(s.Spec, project.Versions(), map[string]string{"latest": project.LatestVersion()})
+	if err != nil {
+		return entry, err
+	}
+	entry.Version = version
+	if file, ok := project.Artifact(version); ok {
+		entry.Resolved, entry.Integrity = file.URL, file.SRI()
+	}
+	return entry, nil
+}
+
+// syncLockfile refreshes the entries of servers a command just changed, given
+// as lockfile.Key values, in an existing lock. Other entries are left alone
+// so that unrelated drift still shows in `lock verify`, and servers in
+// scopes the lock does not cover are ignored. It reports whether there was
+// anything to refresh.
+func syncLockfile(ctx context.Context, keys ...string) (bool, error) {
+	lock, err := lockfile.Load(defaultLockPath())
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("%s not refreshed: %w", lockfile.FileName, err)
+	}
+	keys = slices.DeleteFunc(slices.Clone(keys), func(key string) bool {
+		scope, _, _ := strings.Cut(key, "/")
+		return !lock.Covers(scope)
+	})
+	if len(keys) == 0 {
+		return false, nil
+	}
+	if err := runLock(ctx, lockOptions{only: keys, quiet: true}); err != nil {
+		return true, fmt.Errorf("%s not refreshed: %w", lockfile.FileName, err)
+	}
+	return true, nil
+}
+
+// reportLockSync prints the outcome of syncLockfile. Failures only warn,
+// since the change itself succeeded.
+func reportLockSync(synced bool, err error) {
+	switch {
+	case err != nil:
+		warnf("%v", err)
+	case synced:
+		fmt.Printf("Updated %s.\n", lockfile.FileName)
+	}
+}
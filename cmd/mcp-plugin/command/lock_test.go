@@ -0,0 +1,88 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package command
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/lockfile"
+)
+
+func TestRunLock_OnlyKeepsOtherEntries(t *testing.T) {
+	homeDirFlag = testHome(t, `{"mcpServers": {}}`)
+	t.Cleanup(func() { homeDirFlag = "" })
+
+	path := filepath.Join(t.TempDir(), lockfile.FileName)
+	previous := lockfile.New(path, []string{"user", "project"})
+	for _, name := range []string{"removed", "gone", "elsewhere"} {
+		scope := "user"
+		if name == "elsewhere" {
+			scope = "project"
+		}
+		previous.Servers[lockfile.Key(scope, name)] = lockfile.Entry{
+			Name: name, Scope: scope, Registry: "npm", Package: name, Version: "1.0.0",
+		}
+	}
+	if err := previous.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// "removed" was just removed; the other locked servers are missing from
+	// the configuration too, but were not part of this change.
+	if err := runLock(context.Background(), lockOptions{path: path, only: []string{"user/removed"}, quiet: true}); err != nil {
+		t.Fatalf("runLock() error = %v", err)
+	}
+
+	lock, err := lockfile.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := lock.Servers["user/removed"]; ok {
+		t.Error("refreshed entry of a removed server was kept")
+	}
+	for _, key := range []string{"user/gone", "project/elsewhere"} {
+		if _, ok := lock.Servers[key]; !ok {
+			t.Errorf("%s dropped from the lock: %v", key, lock.Servers)
+		}
+	}
+}
+
+func TestSyncLockfile_SkipsUncoveredScopes(t *testing.T) {
+	homeDirFlag = testHome(t, `{"mcpServers": {}}`)
+	t.Cleanup(func() { homeDirFlag = "" })
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if err := os.WriteFile(filepath.Join(dir, ".mcp.json"), []byte(`{"mcpServers": {}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without --scope the lock covers the project scope only.
+	if err := runLock(context.Background(), lockOptions{quiet: true}); err != nil {
+		t.Fatalf("runLock() error = %v", err)
+	}
+	path := defaultLockPath()
+	lock, err := lockfile.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(lock.Scopes, []string{"project"}) {
+		t.Errorf("default scopes = %v, want [project]", lock.Scopes)
+	}
+
+	before, _ := os.Stat(path)
+	synced, err := syncLockfile(context.Background(), lockfile.Key("user", "mine"), lockfile.Key("local", "also-mine"))
+	if synced || err != nil {
+		t.Errorf("syncLockfile(user, local) = %v, %v; want nothing to do", synced, err)
+	}
+	if after, _ := os.Stat(path); !after.ModTime().Equal(before.ModTime()) {
+		t.Error("a change in a personal scope rewrote the shared lock")
+	}
+	if synced, err := syncLockfile(context.Background(), lockfile.Key("project", "shared")); !synced || err != nil {
+		t.Errorf("syncLockfile(project) = %v, %v; want a refresh", synced, err)
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package command
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/infrastructure/npm"
+	"github.com/spf13/cobra"
+)
+
+// npmCacheTTL is how long registry responses are used without asking the
+// registry again. Older ones are revalidated by ETag.
+const npmCacheTTL = 10 * time.Minute
+
+// npmFlags are the registry flags of commands that query npm.
+type npmFlags struct {
+	registry string
+	offline  bool
+}
+
+func addNpmFlags(cmd *cobra.Command, f *npmFlags) {
+	cmd.Flags().StringVar(&f.registry, "registry", "", "npm registry URL (default: from .npmrc, else registry.npmjs.org)")
+	cmd.Flags().BoolVar(&f.offline, "offline", false, "Use cached registry responses only")
+}
+
+// newNpmClient returns an npm client configured from the user's and the
+// current directory's .npmrc, caching responses in the state directory.
+func newNpmClient(f npmFlags) *npm.Client {
+	opts := []npm.Option{
+		npm.WithCache(filepath.Join(cacheDir(), "npm"), npmCacheTTL),
+		npm.WithOffline(f.offline),
+	}
+	rc, err := npm.LoadNpmrc(npm.NpmrcPaths()...)
+	if err != nil {
+		warnf("ignoring .npmrc: %v", err)
+	} else {
+		opts = append(opts, npm.WithNpmrc(rc))
+	}
+	if f.registry != "" {
+		opts = append(opts, npm.WithBaseURL(f.registry))
+	}
+	return npm.NewClient(opts...)
+}
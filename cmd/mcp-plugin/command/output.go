@@ -0,0 +1,139 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats for the global --output flag.
+const (
+	outputText  = "text"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+	outputTable = "table"
+)
+
+// apiVersion identifies the schema of machine-readable output. Bump it when
+// a field is renamed or removed; adding fields is backward compatible.
+const apiVersion = "mcp-plugin/v1"
+
+// outputFormat holds the value of the global --output flag.
+var outputFormat = outputText
+
+// warnf prints a warning that does not stop the command. The ui redirects
+// it to its message line, since stderr would write over the screen.
+var warnf = func(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, "Warning: "+format+"\n", args...)
+}
+
+// document is the envelope of all JSON and YAML output.
+type document struct {
+	APIVersion string `json:"api_version"`
+	Kind       string `json:"kind"`
+	Data       any    `json:"data"`
+}
+
+// table is the plain, undecorated representation used by --output table.
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+func validateOutputFormat() error {
+	switch outputFormat {
+	case outputText, outputJSON, outputYAML, outputTable:
+		return nil
+	default:
+		return fmt.Errorf("invalid output format %q: expected text, json, yaml or table", outputFormat)
+	}
+}
+
+// textOutput reports whether human-readable output was requested. Commands
+// print progress messages only in this mode so structured output stays clean.
+func textOutput() bool {
+	return outputFormat == outputText
+}
+
+// render writes data in the selected structured format. It returns false in
+// text mode, leaving the decorated human output to the caller.
+func render(kind string, data any, toTable func() table) (bool, error) {
+	switch outputFormat {
+	case outputJSON:
+		return true, writeJSON(document{APIVersion: apiVersion, Kind: kind, Data: data})
+	case outputYAML:
+		return true, writeYAML(document{APIVersion: apiVersion, Kind: kind, Data: data})
+	case outputTable:
+		return true, writeTable(toTable())
+	default:
+		return false, nil
+	}
+}
+
+func writeJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(v)
+}
+
+// writeYAML renders v through its JSON form so YAML and JSON output share
+// the same field names and order.
+func writeYAML(v any) error {
+	return encodeYAML(os.Stdout, v)
+}
+
+func encodeYAML(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	// YAML is a superset of JSON; decoding into a node keeps key order.
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	resetYAMLStyle(&node)
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// resetYAMLStyle drops the flow/quoted styles inherited from JSON syntax so
+// the output uses block style and quotes only where YAML requires it.
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
+
+func writeTable(t table) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(t.header, "\t"))
+	for _, row := range t.rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+// orDash keeps empty table cells visible.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/config"
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/infrastructure/pypi"
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/versions"
+	"github.com/spf13/cobra"
+)
+
+func newPinCmd() *cobra.Command {
+	opts := updateOptions{target: updateTarget{pin: true}}
+
+	cmd := &cobra.Command{
+		Use:   "pin [server]",
+		Short: "Pin npx and uvx servers to the versions they resolve to now",
+		Long: `Lock npx and uvx servers to exact versions.
+
+Unpinned servers ('npx -y pkg', 'uvx pkg') run whatever is latest each time
+they start, and tags or ranges ('pkg@next', 'pkg@^1.2') move too. pin
+resolves each one against the registry the way npx or uvx would today and
+writes that exact version back, so the server only changes through
+'mcp-plugin update'. Servers already on an exact version are left alone.
+
+Examples:
+  # Show what would be pinned
+  mcp-plugin pin --all --dry-run
+
+  # Pin every npx and uvx server
+  mcp-plugin pin --all
+
+  # Pin one server
+  mcp-plugin pin context7`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !opts.all && len(args) == 0 {
+				return fmt.Errorf("specify a server name or use --all")
+			}
+			if len(args) > 0 {
+				opts.serverName = args[0]
+			}
+			return runUpdate(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.all, "all", false, "Pin all npx and uvx servers")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Show what would be pinned without making changes")
+	cmd.Flags().StringVarP(&opts.scope, "scope", "s", "", "Only pin servers from this scope (user, project, local)")
+	addNpmFlags(cmd, &opts.npm)
+
+	return cmd
+}
+
+// pinInstallEntry resolves the package of a new npx or uvx entry to an exact
+// version, for `install --pin`.
+func pinInstallEntry(ctx context.Context, entry *config.MCPServerEntry) (string, error) {
+	switch entry.Command {
+	case "npx":
+		name, spec := extractPackageInfo(entry.Args)
+		rel, err := fetchNpmRelease(ctx, newNpmClient(npmFlags{}), name)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve %s: %w", name, err)
+		}
+		version, err := versions.Resolve(spec, rel.versions, rel.tags)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve %s: %w", name, err)
+		}
+		entry.Args = pinNpxArgs(entry.Args, name, version)
+		return version, nil
+
+	case "uvx":
+		pkg, ok := parseUVXArgs(entry.Args)
+		if !ok || pkg.direct || pkg.constraint != "" {
+			return "", fmt.Errorf("--pin needs a PyPI package name, optionally with ==version or @version")
+		}
+		rel, err := fetchPyPIRelease(ctx, pypi.NewClient(), pkg.name)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve %s: %w", pkg.name, err)
+		}
+		version, err := versions.Resolve(pkg.version, rel.versions, rel.tags)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve %s: %w", pkg.name, err)
+		}
+		entry.Args = pkg.pinned(entry.Args, version)
+		return version, nil
+
+	default:
+		return "", fmt.Errorf("--pin applies only to npx and uvx servers")
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/config"
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/policy"
+	"github.com/spf13/cobra"
+)
+
+func newPolicyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "policy",
+		Short: "Audit servers against the allow/deny policy",
+		Long: `Policies restrict which MCP servers may be configured. They are read from
+a system-wide file and from mcp-plugin.policy.yaml next to the project's
+.mcp.json; a server must satisfy both. install, config import, apply and
+update refuse servers that break a policy.
+
+System-wide policy: $MCP_PLUGIN_POLICY, else /etc/mcp-plugin/policy.yaml
+(%ProgramData%\mcp-plugin\policy.yaml on Windows).
+
+  packages:
+    allow: ["@modelcontextprotocol/*", "@upstash/context7-mcp", "mcp-server-*"]
+    deny: ["@modelcontextprotocol/server-puppeteer"]
+  commands: [npx, uvx]           # allowed commands
+  hosts: ["*.corp.example"]      # allowed hosts of HTTP servers
+  require_pinned: true           # npx/uvx packages need an exact version
+  banned_headers: [Cookie]
+
+Patterns are globs ('*' does not cross '/') matched case-insensitively.
+Empty lists place no restriction. A bare command name matches by program
+name; a command with a path must match a pattern in full.`,
+	}
+
+	cmd.AddCommand(newPolicyCheckCmd())
+	return cmd
+}
+
+func newPolicyCheckCmd() *cobra.Command {
+	var scope string
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check the configured servers against the policies",
+		Long: `Check every configured server, including plugin servers, against the
+policies in effect. Exits non-zero if any server breaks a rule.
+
+Examples:
+  mcp-plugin policy check
+  mcp-plugin policy check --scope project -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPolicyCheck(scope)
+		},
+	}
+
+	cmd.Flags().StringVarP(&scope, "scope", "s", "", "Only check servers from this scope (user, project, local, plugin)")
+	return cmd
+}
+
+// PolicyReport is the result of `policy check`.
+type PolicyReport struct {
+	Policies   []string           `json:"policies"`
+	Servers    int                `json:"servers"`
+	Violations []policy.Violation `json:"violations"`
+}
+
+func runPolicyCheck(scope string) error {
+	set, err := loadPolicies()
+	if err != nil {
+		return err
+	}
+	servers, err := newReader().ListMCPServers()
+	if err != nil {
+		return fmt.Errorf("failed to list servers: %w", err)
+	}
+	if servers, err = filterByScope(servers, scope); err != nil {
+		return err
+	}
+
+	report := PolicyReport{Policies: []string{}, Servers: len(servers), Violations: []policy.Violation{}}
+	for _, p := range set {
+		report.Policies = append(report.Policies, p.Path)
+	}
+	for _, s := range servers {
+		entry := config.MCPServerEntry{Command: s.Command, Args: s.Args, URL: s.URL, Headers: s.Headers}
+		report.Violations = append(report.Violations, set.Check(policyServer(s.Name, s.Source, entry))...)
+	}
+
+	var failed error
+	if n := len(report.Violations); n > 0 {
+		failed = fmt.Errorf("%d policy violation(s)", n)
+	}
+
+	if handled, err := render("PolicyReport", report, func() table {
+		t := table{header: []string{"SERVER", "SCOPE", "RULE", "MESSAGE", "POLICY"}}
+		for _, v := range report.Violations {
+			t.rows = append(t.rows, []string{v.Server, v.Scope, string(v.Rule), v.Message, v.Policy})
+		}
+		return t
+	}); handled {
+		if err != nil {
+			return err
+		}
+		return failed
+	}
+
+	if len(set) == 0 {
+		fmt.Printf("No policy found (looked for %s and %s).\n", policy.SystemPath(), repoPolicyPath())
+		return nil
+	}
+	fmt.Println("Policies:")
+	for _, p := range report.Policies {
+		fmt.Printf("  %s\n", p)
+	}
+	fmt.Println()
+	for _, v := range report.Violations {
+		fmt.Printf("⛔ %s (%s): %s\n", v.Server, v.Scope, v.Message)
+	}
+	if failed != nil {
+		fmt.Println()
+		return failed
+	}
+	fmt.Printf("✅ %d server(s) comply.\n", report.Servers)
+	return nil
+}
+
+// repoPolicyPath is the repository's policy, beside its .mcp.json.
+func repoPolicyPath() string {
+	return filepath.Join(projectDir(), policy.RepoFileName)
+}
+
+// loadPolicies reads the system-wide and repository policies.
+func loadPolicies() (policy.Set, error) {
+	return policy.Load(policy.SystemPath(), repoPolicyPath())
+}
+
+// policyServer describes a server entry the way policies see it.
+func policyServer(name, scope string, entry config.MCPServerEntry) policy.Server {
+	command, _ := unwrapExec(entry.Command, entry.Args)
+	s := policy.Server{
+		Name: name, Scope: scope, Command: command, URL: entry.URL,
+		Headers: slices.Sorted(maps.Keys(entry.Headers)),
+	}
+	if ref, ok := registryPackage(entry.Command, entry.Args); ok {
+		s.Package, s.Pinned = ref.name, ref.pinned
+	}
+	return s
+}
+
+// checkPolicy returns an error listing the rules entry breaks, for commands
+// about to write it.
+func checkPolicy(set policy.Set, name string, scope config.Scope, entry config.MCPServerEntry) error {
+	violations := set.Check(policyServer(name, string(scope), entry))
+	if len(violations) == 0 {
+		return nil
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "server '%s' is not allowed by policy:", name)
+	for _, v := range violations {
+		fmt.Fprintf(&b, "\n  - %s (%s)", v.Message, v.Policy)
+	}
+	return errors.New(b.String())
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package command
+
+import (
+	"testing"
+
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/config"
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/policy"
+)
+
+func TestCheckPolicy_ExecWrapper(t *testing.T) {
+	pol, err := policy.Parse([]byte(`{commands: [npx], packages: {allow: ["@allowed/*"]}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	set := policy.Set{pol}
+	inner := []string{"exec", "--", "npx", "-y", "@allowed/pkg"}
+
+	// The real wrapper is checked by the command it runs.
+	if err := checkPolicy(set, "ok", config.ScopeProject, config.MCPServerEntry{Command: binaryName, Args: inner}); err != nil {
+		t.Errorf("wrapped allowed server: %v", err)
+	}
+
+	// Another binary named mcp-plugin is checked as itself.
+	for _, command := range []string{"./tools/mcp-plugin", "/tmp/x/mcp-plugin"} {
+		entry := config.MCPServerEntry{Command: command, Args: inner}
+		if err := checkPolicy(set, "fake", config.ScopeProject, entry); err == nil {
+			t.Errorf("%s exec -- npx passed the policy", command)
+		}
+	}
+}
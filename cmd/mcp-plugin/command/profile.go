@@ -0,0 +1,70 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/config"
+)
+
+// Values of the global --home and --config-dir flags.
+var (
+	homeDirFlag   string
+	configDirFlag string
+)
+
+// configOptions turns the global flags into Reader/Writer options. Without
+// flags, config.NewReader and config.NewWriter fall back to CLAUDE_CONFIG_DIR.
+func configOptions() []config.Option {
+	var opts []config.Option
+	if homeDirFlag != "" {
+		opts = append(opts, config.WithHomeDir(homeDirFlag))
+	}
+	if configDirFlag != "" {
+		opts = append(opts, config.WithConfigDir(configDirFlag))
+	}
+	return opts
+}
+
+// newReader returns a Reader honoring the global config location flags.
+func newReader() *config.Reader {
+	return config.NewReader(configOptions()...)
+}
+
+// newWriter returns a Writer honoring the global config location flags.
+func newWriter() *config.Writer {
+	return config.NewWriter(configOptions()...)
+}
+
+// configOverride describes which setting relocated Claude Code's files, or
+// returns "" when the defaults under the home directory are used.
+func configOverride() string {
+	switch {
+	case configDirFlag != "":
+		return "--config-dir"
+	case homeDirFlag != "":
+		return "--home"
+	case os.Getenv(config.ConfigDirEnv) != "":
+		return config.ConfigDirEnv
+	default:
+		return ""
+	}
+}
+
+// cacheDir holds mcp-plugin's disposable caches: registry responses, the
+// downloaded catalog and server inventories. They live in the user cache
+// directory rather than next to Claude's config and its backups; with --home
+// they live under that home instead.
+func cacheDir() string {
+	if homeDirFlag != "" {
+		return filepath.Join(homeDirFlag, ".cache", "mcp-plugin")
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return filepath.Join(newWriter().StateDir(), "cache")
+	}
+	return filepath.Join(dir, "mcp-plugin")
+}
@@ -6,11 +6,15 @@ package command
 import (
 	"fmt"
 
-	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/config"
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/lockfile"
 	"github.com/spf13/cobra"
 )
 
-var removeForce bool
+var (
+	removeForce   bool
+	removeProject bool
+	removeScope   string
+)
 
 func newRemoveCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -19,20 +23,26 @@ func newRemoveCmd() *cobra.Command {
 		Short:   "Remove an MCP server",
 		Long: `Remove an MCP server from Claude Code configuration.
 
-This removes the server entry from ~/.claude.json but does not uninstall
-any npm or Python packages that may have been installed.
+This removes the server entry from the selected scope (~/.claude.json by
+default) but does not uninstall any npm or Python packages that may have
+been installed.
 
 Examples:
   # Remove an MCP server
   mcp-plugin remove context7
 
   # Remove without confirmation
-  mcp-plugin remove context7 --force`,
+  mcp-plugin remove context7 --force
+
+  # Remove a server from the repository's .mcp.json
+  mcp-plugin remove playwright --project`,
 		Args: cobra.ExactArgs(1),
 		RunE: runRemove,
 	}
 
 	cmd.Flags().BoolVarP(&removeForce, "force", "f", false, "Skip confirmation")
+	addScopeFlag(cmd, &removeScope)
+	addProjectFlag(cmd, &removeProject)
 
 	return cmd
 }
@@ -40,7 +50,14 @@ Examples:
 func runRemove(cmd *cobra.Command, args []string) error {
 	name := args[0]
 
-	writer := config.NewWriter()
+	scope, err := resolveScopeFlags(cmd, removeScope, removeProject)
+	if err != nil {
+		return err
+	}
+	writer, err := scopedWriter(scope)
+	if err != nil {
+		return err
+	}
 
 	// Check if server exists
 	exists, err := writer.MCPServerExists(name)
@@ -49,21 +66,21 @@ func runRemove(cmd *cobra.Command, args []string) error {
 	}
 
 	if !exists {
-		servers, listErr := writer.ListMCPServersGlobal()
-		fmt.Printf("MCP server '%s' not found.\n\n", name)
+		servers, listErr := writer.ListMCPServerEntries()
+		fmt.Printf("MCP server '%s' not found in %s scope.\n\n", name, writer.Scope())
 		if listErr == nil && len(servers) > 0 {
 			fmt.Println("Available servers:")
 			for serverName := range servers {
 				fmt.Printf("  - %s\n", serverName)
 			}
 		} else if listErr == nil {
-			fmt.Println("No MCP servers installed.")
+			fmt.Printf("No MCP servers installed in %s scope.\n", writer.Scope())
 		}
 		return fmt.Errorf("server not found")
 	}
 
 	// Get server info before removing
-	servers, err := writer.ListMCPServersGlobal()
+	servers, err := writer.ListMCPServerEntries()
 	if err != nil {
 		return fmt.Errorf("failed to read servers: %w", err)
 	}
@@ -82,6 +99,7 @@ func runRemove(cmd *cobra.Command, args []string) error {
 	} else if serverInfo.URL != "" {
 		fmt.Printf("  (was: %s)\n", serverInfo.URL)
 	}
+	reportLockSync(syncLockfile(cmd.Context(), lockfile.Key(string(writer.Scope()), name)))
 
 	fmt.Println("\nNote: Restart Claude Code for changes to take effect.")
 
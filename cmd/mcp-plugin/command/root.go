@@ -5,6 +5,12 @@
 package command
 
 import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/config"
 	"github.com/spf13/cobra"
 )
 
@@ -30,17 +36,37 @@ Examples:
   mcp-plugin enable context7
 
   # Disable a server
-  mcp-plugin disable context7`,
+  mcp-plugin disable context7
+
+  # Machine-readable output for scripts
+  mcp-plugin list --output json
+
+  # Manage a second Claude profile
+  mcp-plugin list --config-dir ~/.claude-work`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return validateOutputFormat()
+	},
 }
 
-// Execute runs the root command.
+// Execute runs the root command. Commands get a context that is cancelled
+// on Ctrl-C or SIGTERM.
 func Execute() error {
-	return rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return rootCmd.ExecuteContext(ctx)
 }
 
 func init() {
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", outputText,
+		"Output format for read commands: text, json, yaml or table")
+	rootCmd.PersistentFlags().StringVar(&homeDirFlag, "home", "",
+		"Treat this directory as the home directory (~/.claude.json, ~/.claude)")
+	rootCmd.PersistentFlags().StringVar(&configDirFlag, "config-dir", "",
+		"Claude config directory, like "+config.ConfigDirEnv+" (default ~/.claude)")
+	rootCmd.MarkFlagsMutuallyExclusive("home", "config-dir")
+
 	rootCmd.AddCommand(newListCmd())
 	rootCmd.AddCommand(newConfigCmd())
 	rootCmd.AddCommand(newVersionCmd())
@@ -52,4 +78,14 @@ func init() {
 	rootCmd.AddCommand(newInfoCmd())
 	rootCmd.AddCommand(newServerCmd())
 	rootCmd.AddCommand(newUpdateCmd())
+	rootCmd.AddCommand(newPinCmd())
+	rootCmd.AddCommand(newLockCmd())
+	rootCmd.AddCommand(newVerifyCmd())
+	rootCmd.AddCommand(newPolicyCmd())
+	rootCmd.AddCommand(newPlanCmd())
+	rootCmd.AddCommand(newApplyCmd())
+	rootCmd.AddCommand(newSecretCmd())
+	rootCmd.AddCommand(newExecCmd())
+	rootCmd.AddCommand(newCatalogCmd())
+	rootCmd.AddCommand(newUICmd())
 }
@@ -0,0 +1,75 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package command
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+const scopeFlagUsage = "Config scope: user (~/.claude.json), project (.mcp.json), local (current project in ~/.claude.json)"
+
+// addScopeFlag registers the --scope flag for commands that write servers.
+func addScopeFlag(cmd *cobra.Command, target *string) {
+	cmd.Flags().StringVarP(target, "scope", "s", string(config.ScopeUser), scopeFlagUsage)
+}
+
+// addProjectFlag registers --project, a shorthand for --scope project.
+func addProjectFlag(cmd *cobra.Command, target *bool) {
+	cmd.Flags().BoolVar(target, "project", false, "Use the project's .mcp.json (same as --scope project)")
+}
+
+// resolveScopeFlags combines --scope and --project into one scope name.
+func resolveScopeFlags(cmd *cobra.Command, scope string, project bool) (string, error) {
+	if !project {
+		return scope, nil
+	}
+	if cmd.Flags().Changed("scope") && scope != string(config.ScopeProject) {
+		return "", fmt.Errorf("--project conflicts with --scope %s", scope)
+	}
+	return string(config.ScopeProject), nil
+}
+
+// scopedWriter returns a Writer for a --scope flag value.
+func scopedWriter(scope string) (*config.Writer, error) {
+	s, err := config.ParseScope(scope)
+	if err != nil {
+		return nil, err
+	}
+	return newWriter().WithScope(s), nil
+}
+
+// projectDir is the directory of the project's .mcp.json, found the same way
+// `install --project` finds it. Project-wide files such as the lockfile and
+// the repository policy live there.
+func projectDir() string {
+	return filepath.Dir(newWriter().WithScope(config.ScopeProject).ServersPath())
+}
+
+// filterByScope keeps servers from the given scope; an empty scope keeps all.
+func filterByScope(servers []config.MCPServer, scope string) ([]config.MCPServer, error) {
+	if scope == "" {
+		return servers, nil
+	}
+	want := config.ScopePlugin
+	if !strings.EqualFold(scope, string(config.ScopePlugin)) {
+		parsed, err := config.ParseScope(scope)
+		if err != nil {
+			return nil, fmt.Errorf("invalid scope %q: expected user, project, local or plugin", scope)
+		}
+		want = parsed
+	}
+
+	var filtered []config.MCPServer
+	for _, s := range servers {
+		if s.Source == string(want) {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered, nil
+}
@@ -0,0 +1,27 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package command
+
+import (
+	"testing"
+
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/config"
+)
+
+func TestFilterByScope_CaseInsensitive(t *testing.T) {
+	servers := []config.MCPServer{
+		{Name: "a", Source: "user"},
+		{Name: "b", Source: "project"},
+		{Name: "c", Source: "plugin"},
+	}
+	for scope, want := range map[string]string{"User": "a", "PROJECT": "b", "Plugin": "c"} {
+		got, err := filterByScope(servers, scope)
+		if err != nil || len(got) != 1 || got[0].Name != want {
+			t.Errorf("filterByScope(%q) = %+v, %v; want %s", scope, got, err, want)
+		}
+	}
+	if _, err := filterByScope(servers, "global"); err == nil {
+		t.Error("filterByScope(global) error = nil")
+	}
+}
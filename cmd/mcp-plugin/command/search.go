@@ -4,15 +4,25 @@
 package command
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/catalog"
 	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/infrastructure/npm"
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/infrastructure/pypi"
 	"github.com/spf13/cobra"
 )
 
 func newSearchCmd() *cobra.Command {
-	var limit int
+	var (
+		limit   int
+		npmf    npmFlags
+		usePyPI bool
+	)
 
 	cmd := &cobra.Command{
 		Use:   "search <query>",
@@ -20,7 +30,12 @@ func newSearchCmd() *cobra.Command {
 		Long: `Search npm registry for MCP-related packages.
 
 This searches npm for packages containing "mcp" along with your query.
-Use this to discover MCP servers you can use with Claude Code.
+Use this to discover MCP servers you can use with Claude Code. Matching
+catalog entries, which install by name alone, are listed first.
+
+PyPI has no search API, so --pypi instead looks up the names Python MCP
+servers are usually published under: <query>, mcp-server-<query>,
+<query>-mcp, mcp-<query> and <query>-mcp-server.
 
 Examples:
   # Search for kubernetes-related MCP packages
@@ -30,28 +45,77 @@ Examples:
   mcp-plugin search database
 
   # Limit results
-  mcp-plugin search postgres --limit 5`,
+  mcp-plugin search postgres --limit 5
+
+  # Look for a Python MCP server for git
+  mcp-plugin search git --pypi`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runSearch(args[0], limit)
+			if usePyPI {
+				return runPyPISearch(cmd.Context(), args[0])
+			}
+			return runSearch(cmd.Context(), args[0], limit, npmf)
 		},
 	}
 
 	cmd.Flags().IntVarP(&limit, "limit", "l", 10, "Maximum number of results")
+	addNpmFlags(cmd, &npmf)
+	cmd.Flags().BoolVar(&usePyPI, "pypi", false, "Look up Python packages on PyPI instead")
 
 	return cmd
 }
 
-func runSearch(query string, limit int) error {
-	client := npm.NewClient()
+// searchView is the structured form of `search`.
+type searchView struct {
+	Query    string              `json:"query"`
+	Total    int                 `json:"total"`
+	Packages []npm.PackageObject `json:"packages"`
+	// Catalog are the matching catalog entries.
+	Catalog []*catalog.Template `json:"catalog"`
+}
+
+func runSearch(ctx context.Context, query string, limit int, npmf npmFlags) error {
+	client := newNpmClient(npmf)
 
-	fmt.Printf("Searching npm for MCP packages matching '%s'...\n\n", query)
+	if textOutput() {
+		fmt.Printf("Searching npm for MCP packages matching '%s'...\n\n", query)
+	}
 
-	results, err := client.Search(query, limit)
+	results, err := client.Search(ctx, query, limit)
 	if err != nil {
 		return fmt.Errorf("search failed: %w", err)
 	}
 
+	view := searchView{Query: query, Total: results.Total, Packages: results.Objects, Catalog: []*catalog.Template{}}
+	if view.Packages == nil {
+		view.Packages = []npm.PackageObject{}
+	}
+	if cat, err := loadCatalog(); err != nil {
+		warnf("%v", err)
+	} else if found := cat.Search(query); found != nil {
+		view.Catalog = found
+	}
+	if handled, err := render("SearchResult", view, func() table {
+		t := table{header: []string{"NAME", "VERSION", "SCORE", "DESCRIPTION"}}
+		for _, obj := range results.Objects {
+			t.rows = append(t.rows, []string{
+				obj.Package.Name, obj.Package.Version,
+				strconv.FormatFloat(obj.Score.Final, 'f', 2, 64), orDash(obj.Package.Description),
+			})
+		}
+		return t
+	}); handled {
+		return err
+	}
+
+	if len(view.Catalog) > 0 {
+		fmt.Println("In the catalog (install with 'mcp-plugin install <name>'):")
+		for _, t := range view.Catalog {
+			fmt.Printf("  📦 %s - %s\n", t.Name, t.Description)
+		}
+		fmt.Println()
+	}
+
 	if len(results.Objects) == 0 {
 		fmt.Println("No packages found.")
 		return nil
@@ -91,3 +155,87 @@ func runSearch(query string, limit int) error {
 
 	return nil
 }
+
+// pypiSearchView is the structured form of `search --pypi`.
+type pypiSearchView struct {
+	Query    string          `json:"query"`
+	Packages []pypiSearchHit `json:"packages"`
+}
+
+type pypiSearchHit struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Summary string `json:"summary,omitempty"`
+}
+
+func runPyPISearch(ctx context.Context, query string) error {
+	if textOutput() {
+		fmt.Printf("Looking up MCP packages named after '%s' on PyPI...\n\n", query)
+	}
+
+	client := pypi.NewClient()
+	candidates := pypiCandidates(query)
+	type lookup struct {
+		project *pypi.Project
+		err     error
+	}
+	lookups := runChecks(ctx, candidates, len(candidates), func(ctx context.Context, name string) lookup {
+		project, err := client.GetProject(ctx, name)
+		return lookup{project, err}
+	})
+
+	view := pypiSearchView{Query: query, Packages: []pypiSearchHit{}}
+	for _, l := range lookups {
+		if errors.Is(l.err, pypi.ErrNotFound) {
+			continue
+		}
+		if l.err != nil {
+			return fmt.Errorf("search failed: %w", l.err)
+		}
+		info := l.project.Info
+		view.Packages = append(view.Packages, pypiSearchHit{Name: info.Name, Version: l.project.LatestVersion(), Summary: info.Summary})
+	}
+
+	if handled, err := render("PyPISearchResult", view, func() table {
+		t := table{header: []string{"NAME", "VERSION", "DESCRIPTION"}}
+		for _, hit := range view.Packages {
+			t.rows = append(t.rows, []string{hit.Name, hit.Version, orDash(hit.Summary)})
+		}
+		return t
+	}); handled {
+		return err
+	}
+
+	if len(view.Packages) == 0 {
+		fmt.Printf("No packages found (tried %s).\n", strings.Join(candidates, ", "))
+		return nil
+	}
+
+	for _, hit := range view.Packages {
+		fmt.Printf("  %s==%s\n", hit.Name, hit.Version)
+		if hit.Summary != "" {
+			desc := hit.Summary
+			if len(desc) > 70 {
+				desc = desc[:67] + "..."
+			}
+			fmt.Printf("    %s\n", desc)
+		}
+		fmt.Printf("    Usage: uvx %s\n\n", pypi.Normalize(hit.Name))
+	}
+
+	fmt.Println("Use 'mcp-plugin info --pypi <package>' for more details.")
+	return nil
+}
+
+// pypiCandidates returns the names a Python MCP server for query is likely
+// published under, without duplicates.
+func pypiCandidates(query string) []string {
+	q := pypi.Normalize(query)
+	var names []string
+	for _, name := range []string{q, "mcp-server-" + q, q + "-mcp", "mcp-" + q, q + "-mcp-server"} {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/config"
+)
+
+func TestExportSecrets_ArgsAndURLs(t *testing.T) {
+	servers := map[string]config.MCPServerEntry{
+		"pg":  {Command: "npx", Args: []string{"-y", "server-postgres", "postgresql://app:s3cret@db:5432/app"}},
+		"api": {Command: "api-mcp", Args: []string{"--api-key=abc123", "--token", "tok", "--verbose", "--region", "eu", "--auth=${env:API_AUTH}"}},
+		"web": {Type: "http", URL: "https://mcp.example.com/mcp?api_key=abc&team=core"},
+	}
+
+	got, err := exportSecrets(servers, secretsRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"-y", "server-postgres", "postgresql://app:${env:PG_PASSWORD}@db:5432/app"}; !reflect.DeepEqual(got["pg"].Args, want) {
+		t.Errorf("pg args = %v, want %v", got["pg"].Args, want)
+	}
+	want := []string{"--api-key=${env:API_API_KEY}", "--token", "${env:API_TOKEN}", "--verbose", "--region", "eu", "--auth=${env:API_AUTH}"}
+	if !reflect.DeepEqual(got["api"].Args, want) {
+		t.Errorf("api args = %v, want %v", got["api"].Args, want)
+	}
+	if want := "https://mcp.example.com/mcp?api_key=${env:WEB_API_KEY}&team=core"; got["web"].URL != want {
+		t.Errorf("web url = %s, want %s", got["web"].URL, want)
+	}
+
+	got, err = exportSecrets(servers, secretsRedact)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, entry := range got {
+		if !hasRedacted(entry) {
+			t.Errorf("%s = %+v, want credentials redacted", name, entry)
+		}
+		for _, s := range append(entry.Args, entry.URL) {
+			if strings.Contains(s, "s3cret") || strings.Contains(s, "abc") || s == "tok" {
+				t.Errorf("%s still holds a credential: %q", name, s)
+			}
+		}
+	}
+}
+
+func TestConfigImport_RejectsRedacted(t *testing.T) {
+	home := testHome(t, `{"mcpServers": {}}`)
+	file := filepath.Join(t.TempDir(), "export.json")
+	export := `{"version": "1.0", "servers": {
+		"redacted": {"command": "npx", "env": {"API_KEY": "REDACTED"}},
+		"clean": {"command": "npx", "env": {"API_KEY": "${env:API_KEY}"}}
+	}}`
+	if err := os.WriteFile(file, []byte(export), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runCommand(t, home, "config", "import", file); err != nil {
+		t.Fatalf("config import: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(home, ".claude.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "REDACTED") || !strings.Contains(string(data), `"clean"`) {
+		t.Errorf("imported config:\n%s\nwant only the clean server", data)
+	}
+}
@@ -5,13 +5,14 @@ package command
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"net/http"
 	"os/exec"
 	"strings"
 	"time"
 
 	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/config"
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/probe"
 	"github.com/spf13/cobra"
 )
 
@@ -32,17 +33,21 @@ Examples:
   mcp-plugin server status context7
 
   # Show detailed server information
-  mcp-plugin server info kubernetes`,
+  mcp-plugin server info kubernetes
+
+  # List the tools a server offers
+  mcp-plugin server tools context7`,
 	}
 
 	cmd.AddCommand(newServerStatusCmd())
 	cmd.AddCommand(newServerInfoCmd())
+	cmd.AddCommand(newServerToolsCmd())
 
 	return cmd
 }
 
 func newServerStatusCmd() *cobra.Command {
-	var checkHealth bool
+	var opts healthOptions
 
 	cmd := &cobra.Command{
 		Use:   "status [server]",
@@ -55,8 +60,16 @@ With a server name, checks only that specific server.
 Status checks include:
 - Configuration validity
 - Enabled/disabled state
-- HTTP server reachability (with --health flag)
+- HTTP servers (with --health flag): an MCP initialize request sent with the
+  configured headers, reporting protocol version and capabilities, and telling
+  rejected credentials apart from a URL that does not serve MCP
 - Command availability (with --health flag)
+- MCP handshake (with --probe): starts each command server with its args and
+  env, sends initialize, reports the server name, version and tool count,
+  then shuts it down. --probe implies --health.
+
+Servers are checked concurrently (--concurrency). Each check has its own
+--timeout, and --deadline bounds the whole run; Ctrl-C stops it early.
 
 Examples:
   # Quick status of all servers
@@ -66,13 +79,22 @@ Examples:
   mcp-plugin server status --health
 
   # Check specific server
-  mcp-plugin server status context7 --health`,
+  mcp-plugin server status context7 --health
+
+  # Start a server and verify it completes the MCP handshake
+  mcp-plugin server status serena --probe --timeout 1m`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runServerStatus(args, checkHealth)
+			if err := opts.validate(); err != nil {
+				return err
+			}
+			opts.enabled = opts.enabled || opts.probe
+			return runServerStatus(cmd.Context(), args, opts)
 		},
 	}
 
-	cmd.Flags().BoolVar(&checkHealth, "health", false, "Perform health checks (HTTP ping, command verification)")
+	cmd.Flags().BoolVar(&opts.enabled, "health", false, "Perform health checks (HTTP handshake, command verification)")
+	cmd.Flags().BoolVar(&opts.probe, "probe", false, "Start command servers and perform an MCP handshake")
+	addCheckFlags(cmd, &opts.checkFlags, "Time limit for each check (default 10s, 30s for command servers with --probe)")
 
 	return cmd
 }
@@ -85,7 +107,7 @@ func newServerInfoCmd() *cobra.Command {
 
 Shows:
 - Server type (http, command, stdio)
-- Configuration source file
+- Configuration scope and source file
 - Command and arguments (for command-based servers)
 - URL and headers (for HTTP servers)
 - Environment variables
@@ -95,101 +117,155 @@ Examples:
   mcp-plugin server info kubernetes`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runServerInfo(args[0])
+			return runServerInfo(cmd.Context(), args[0])
 		},
 	}
 
 	return cmd
 }
 
-func runServerStatus(args []string, checkHealth bool) error {
-	reader := config.NewReader()
+// serverStatusItem is a server with its optional health check result.
+type serverStatusItem struct {
+	config.MCPServer
+	Health *ValidationResult `json:"health,omitempty"`
+	Probe  *probe.Result     `json:"probe,omitempty"`
+}
+
+// healthOptions selects the checks `server status` runs. Command servers are
+// only started for a handshake with probe set.
+type healthOptions struct {
+	checkFlags
+	enabled bool
+	probe   bool
+}
+
+func runServerStatus(ctx context.Context, args []string, opts healthOptions) error {
+	reader := newReader()
 
 	servers, err := reader.ListMCPServers()
 	if err != nil {
 		return fmt.Errorf("failed to list servers: %w", err)
 	}
 
-	if len(servers) == 0 {
-		fmt.Println("No MCP servers configured.")
-		return nil
-	}
-
 	// Filter by name if provided
-	var targetName string
 	if len(args) > 0 {
-		targetName = args[0]
+		servers = serversNamed(servers, args[0])
+		if len(servers) == 0 {
+			return fmt.Errorf("server '%s' not found", args[0])
+		}
 	}
 
-	var found bool
-	for _, server := range servers {
-		if targetName != "" && server.Name != targetName {
-			continue
+	checkCtx, cancel := opts.withDeadline(ctx)
+	defer cancel()
+	items := runChecks(checkCtx, servers, opts.concurrency, func(ctx context.Context, server config.MCPServer) serverStatusItem {
+		item := serverStatusItem{MCPServer: maskServer(server)}
+		if opts.enabled {
+			health, result := checkServerHealth(ctx, server, opts)
+			item.Health, item.Probe = &health, result
 		}
-		found = true
-
-		// Basic status
-		status := statusDisabled
-		statusIcon := "○"
-		if server.Enabled {
-			status = statusEnabled
-			statusIcon = "●"
+		return item
+	})
+
+	if handled, err := render("ServerStatusList", items, func() table {
+		return serverStatusTable(items, opts.enabled)
+	}); handled {
+		if err != nil {
+			return err
 		}
+		return interrupted(ctx)
+	}
 
-		fmt.Printf("%s %s (%s)\n", statusIcon, server.Name, status)
-		fmt.Printf("  Type: %s\n", server.Type)
+	if len(items) == 0 {
+		fmt.Println("No MCP servers configured.")
+		return nil
+	}
 
-		if checkHealth {
-			health := checkServerHealth(server)
-			fmt.Printf("  Health: %s\n", health)
+	for _, item := range items {
+		icon := "○"
+		if item.Enabled {
+			icon = "●"
 		}
 
+		fmt.Printf("%s %s (%s)\n", icon, item.Name, serverStatus(item.MCPServer))
+		fmt.Printf("  Type: %s\n", item.Type)
+		if item.Health != nil {
+			fmt.Printf("  Health: %s\n", formatHealth(*item.Health))
+		}
 		fmt.Println()
 	}
 
-	if targetName != "" && !found {
-		return fmt.Errorf("server '%s' not found", targetName)
+	return interrupted(ctx)
+}
+
+func serverStatusTable(items []serverStatusItem, withHealth bool) table {
+	t := table{header: []string{"NAME", "STATUS", "TYPE", "SCOPE"}}
+	if withHealth {
+		t.header = append(t.header, "HEALTH", "MESSAGE")
 	}
+	for _, item := range items {
+		row := []string{item.Name, serverStatus(item.MCPServer), item.Type, item.Source}
+		if item.Health != nil {
+			row = append(row, item.Health.Status, item.Health.Message)
+		}
+		t.rows = append(t.rows, row)
+	}
+	return t
+}
 
-	return nil
+func serversNamed(servers []config.MCPServer, name string) []config.MCPServer {
+	var matched []config.MCPServer
+	for _, s := range servers {
+		if s.Name == name {
+			matched = append(matched, s)
+		}
+	}
+	return matched
 }
 
-func runServerInfo(name string) error {
-	reader := config.NewReader()
+func runServerInfo(ctx context.Context, name string) error {
+	reader := newReader()
 
 	servers, err := reader.ListMCPServers()
 	if err != nil {
 		return fmt.Errorf("failed to list servers: %w", err)
 	}
 
-	for _, server := range servers {
-		if server.Name != name {
-			continue
-		}
-		printServerInfo(server)
-		return nil
+	matched := serversNamed(servers, name)
+	if len(matched) == 0 {
+		return fmt.Errorf("server '%s' not found", name)
+	}
+	server := matched[0]
+
+	health, result := checkServerHealth(ctx, server, healthOptions{enabled: true})
+	item := serverStatusItem{MCPServer: maskServer(server), Health: &health, Probe: result}
+	if handled, err := render("Server", item, func() table {
+		return serverStatusTable([]serverStatusItem{item}, true)
+	}); handled {
+		return err
 	}
 
-	return fmt.Errorf("server '%s' not found", name)
+	printServerInfo(server, health)
+	return nil
 }
 
-func printServerInfo(server config.MCPServer) {
+func printServerInfo(server config.MCPServer, health ValidationResult) {
 	fmt.Printf("Server: %s\n", server.Name)
 	fmt.Printf("─────────────────────────────────\n")
 
-	status := statusDisabled
-	if server.Enabled {
-		status = statusEnabled
-	}
-	fmt.Printf("Status: %s\n", status)
+	fmt.Printf("Status: %s\n", serverStatus(server))
 	fmt.Printf("Type: %s\n", server.Type)
-	fmt.Printf("Source: %s\n", server.Source)
+	fmt.Printf("Scope: %s\n", server.Source)
+	fmt.Printf("Source: %s\n", server.Path)
 
 	printCommandConfig(server)
 	printHTTPConfig(server)
+	if len(server.Env) > 0 {
+		fmt.Printf("\nEnvironment:\n")
+		printEnv(server.Env, "  ")
+	}
 
 	fmt.Printf("\nHealth Check:\n")
-	fmt.Printf("  %s\n", checkServerHealth(server))
+	fmt.Printf("  %s\n", formatHealth(health))
 }
 
 func printCommandConfig(server config.MCPServer) {
@@ -225,60 +301,128 @@ func printHTTPConfig(server config.MCPServer) {
 }
 
 func maskSensitiveHeader(key, value string) string {
-	lower := strings.ToLower(key)
-	if !strings.Contains(lower, "auth") &&
-		!strings.Contains(lower, "token") &&
-		!strings.Contains(lower, "key") {
+	if !isSensitiveHeader(key) {
 		return value
 	}
+	return maskSecret(value)
+}
+
+// isSensitiveHeader reports whether a header usually carries a credential.
+func isSensitiveHeader(key string) bool {
+	return isSensitiveName(key)
+}
+
+// isSensitiveName reports whether a header, flag or query parameter name
+// suggests a credential.
+func isSensitiveName(name string) bool {
+	lower := strings.ToLower(name)
+	for _, word := range []string{"auth", "token", "key", "secret", "password", "passwd", "credential"} {
+		if strings.Contains(lower, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// maskSecret hides all but the edges of a value that may hold a credential.
+func maskSecret(value string) string {
 	if len(value) > 8 {
 		return value[:4] + "..." + value[len(value)-4:]
 	}
 	return "****"
 }
 
-func checkServerHealth(server config.MCPServer) string {
+// Default per-check timeouts: an HTTP handshake, and a started command
+// server, which may first download its package.
+const (
+	httpProbeTimeout    = 10 * time.Second
+	commandProbeTimeout = 30 * time.Second
+)
+
+// checkServerHealth checks one server. HTTP servers always get an MCP
+// handshake; command servers only with opts.probe, since that means starting
+// them.
+func checkServerHealth(ctx context.Context, server config.MCPServer, opts healthOptions) (ValidationResult, *probe.Result) {
+	result := ValidationResult{Server: server.Name, Check: checkHealth}
 	switch {
 	case server.URL != "":
-		return checkHTTPHealth(server.URL)
+		info, err := probeHTTPServer(ctx, server, opts.timeoutOr(httpProbeTimeout))
+		result.Status, result.Message = httpHealthStatus(info, err)
+		return result, info
+	case server.Command != "" && opts.probe:
+		return probeCommandServer(ctx, server, opts.timeoutOr(commandProbeTimeout))
 	case server.Command != "":
-		return checkCommandHealth(server.Command)
+		result.Status, result.Message = checkCommandHealth(server.Command)
 	default:
-		return "⚠️  Unknown server type"
+		result.Status, result.Message = checkStatusWarn, "Unknown server type"
 	}
+	return result, nil
 }
 
-func checkHTTPHealth(url string) string {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// formatHealth renders a health result for human-readable output.
+func formatHealth(r ValidationResult) string {
+	return statusIcon(r.Status) + " " + r.Message
+}
+
+// probeHTTPServer sends an MCP initialize request with the server's
+// configured headers.
+func probeHTTPServer(ctx context.Context, server config.MCPServer, timeout time.Duration) (*probe.Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, http.NoBody)
-	if err != nil {
-		return fmt.Sprintf("❌ Invalid URL: %v", err)
+	return probe.Handshake(ctx, server)
+}
+
+// httpHealthStatus tells a healthy endpoint apart from rejected credentials
+// and from a URL that does not serve MCP.
+func httpHealthStatus(info *probe.Result, err error) (status, message string) {
+	switch {
+	case err == nil:
+		return checkStatusPass, fmt.Sprintf("MCP OK: %s (protocol %s, capabilities: %s)",
+			describeProbe(info), info.ProtocolVersion, orDash(strings.Join(info.Capabilities, ", ")))
+	case errors.Is(err, probe.ErrUnauthorized):
+		return checkStatusWarn, fmt.Sprintf("Reachable, but %v; check the configured headers or log in with /mcp in Claude Code", err)
+	case errors.Is(err, probe.ErrNotMCP):
+		return checkStatusFail, fmt.Sprintf("Reachable, but %v; check the URL", err)
+	case errors.Is(err, probe.ErrUnreachable):
+		return checkStatusFail, fmt.Sprintf("Server %v", err)
+	default:
+		return checkStatusFail, fmt.Sprintf("MCP handshake failed: %v", err)
 	}
+}
 
-	client := &http.Client{Timeout: 5 * time.Second}
-	resp, err := client.Do(req)
+// probeCommandServer runs a one-shot MCP handshake against a command server.
+func probeCommandServer(ctx context.Context, server config.MCPServer, timeout time.Duration) (ValidationResult, *probe.Result) {
+	result := ValidationResult{Server: server.Name, Check: checkProbe}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	info, err := probe.Handshake(ctx, server)
 	if err != nil {
-		// Try GET if HEAD fails
-		req.Method = http.MethodGet
-		resp, err = client.Do(req)
-		if err != nil {
-			return fmt.Sprintf("❌ Unreachable: %v", err)
-		}
+		result.Status, result.Message = checkStatusFail, fmt.Sprintf("MCP handshake failed: %v", err)
+		return result, nil
 	}
-	defer func() { _ = resp.Body.Close() }()
+	result.Status, result.Message = checkStatusPass, "MCP handshake OK: "+describeProbe(info)
+	return result, info
+}
 
-	if resp.StatusCode >= 200 && resp.StatusCode < 400 {
-		return fmt.Sprintf("✅ Reachable (HTTP %d)", resp.StatusCode)
+// describeProbe summarizes a handshake, e.g. "serena 1.2.0, 12 tools".
+func describeProbe(info *probe.Result) string {
+	name := orDash(info.Server)
+	if info.Version != "" {
+		name += " " + info.Version
+	}
+	if info.Tools == 1 {
+		return name + ", 1 tool"
 	}
-	return fmt.Sprintf("⚠️  HTTP %d", resp.StatusCode)
+	return fmt.Sprintf("%s, %d tools", name, info.Tools)
 }
 
-func checkCommandHealth(command string) string {
+func checkCommandHealth(command string) (status, message string) {
 	path, err := exec.LookPath(command)
 	if err != nil {
-		return fmt.Sprintf("❌ Command not found: %s", command)
+		return checkStatusFail, fmt.Sprintf("Command not found: %s", command)
 	}
-	return fmt.Sprintf("✅ Command available: %s", path)
+	return checkStatusPass, fmt.Sprintf("Command available: %s", path)
 }
@@ -0,0 +1,47 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package command
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/config"
+)
+
+func TestCheckServerHealth_HTTPTimeoutWithProbe(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	opts := healthOptions{checkFlags: checkFlags{timeout: 200 * time.Millisecond}, enabled: true, probe: true}
+	start := time.Now()
+	health, _ := checkServerHealth(context.Background(), config.MCPServer{Name: "slow", Type: "http", URL: srv.URL}, opts)
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("HTTP probe took %s, want --timeout to bound it", elapsed)
+	}
+	if health.Status == checkStatusPass {
+		t.Errorf("health = %+v, want a failed check", health)
+	}
+}
+
+func TestRunServerStatus_InterruptedStructured(t *testing.T) {
+	home := testHome(t, `{"mcpServers": {"ctx": {"command": "npx", "args": ["-y", "ctx"]}}}`)
+	homeDirFlag, outputFormat = home, outputJSON
+	t.Cleanup(func() { homeDirFlag, outputFormat = "", outputText })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	opts := healthOptions{checkFlags: checkFlags{concurrency: 1}, enabled: true}
+	if err := runServerStatus(ctx, nil, opts); err == nil || !strings.Contains(err.Error(), "interrupted") {
+		t.Errorf("runServerStatus() error = %v, want interrupted", err)
+	}
+}
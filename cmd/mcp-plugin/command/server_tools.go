@@ -0,0 +1,223 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/config"
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/probe"
+	"github.com/spf13/cobra"
+)
+
+func newServerToolsCmd() *cobra.Command {
+	var (
+		timeout time.Duration
+		noCache bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "tools <server>",
+		Short: "List the tools, prompts and resources a server exposes",
+		Long: `Connect to an MCP server once and list what it offers.
+
+Command servers are started with their configured args and env; HTTP servers
+are called with their configured headers. The server is disconnected as soon
+as tools/list, prompts/list and resources/list have answered.
+
+The result is cached in ~/.claude/mcp-plugin/tools.json, so 'mcp-plugin list'
+can show tool counts without starting servers. --no-cache skips that.
+
+Examples:
+  mcp-plugin server tools context7
+
+  # Full input schemas
+  mcp-plugin server tools context7 -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runServerTools(cmd.Context(), args[0], timeout, !noCache)
+		},
+	}
+
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Second, "Time limit for connecting and listing")
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, "Do not update the tool cache")
+
+	return cmd
+}
+
+// serverToolsView is the structured form of `server tools`.
+type serverToolsView struct {
+	Name  string `json:"name"`
+	Scope string `json:"scope"`
+	*probe.Inventory
+}
+
+func runServerTools(ctx context.Context, name string, timeout time.Duration, updateCache bool) error {
+	servers, err := newReader().ListMCPServers()
+	if err != nil {
+		return fmt.Errorf("failed to list servers: %w", err)
+	}
+	matched := serversNamed(servers, name)
+	if len(matched) == 0 {
+		return fmt.Errorf("server '%s' not found", name)
+	}
+	server := matched[0]
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	inv, err := probe.List(ctx, server)
+	if err != nil {
+		return fmt.Errorf("failed to query server '%s': %w", name, err)
+	}
+
+	if updateCache {
+		if err := cacheInventory(server, inv); err != nil {
+			warnf("failed to update tool cache: %v", err)
+		}
+	}
+
+	view := serverToolsView{Name: server.Name, Scope: server.Source, Inventory: inv}
+	if handled, err := render("ServerTools", view, func() table {
+		return inventoryTable(inv)
+	}); handled {
+		return err
+	}
+
+	printInventory(server, inv)
+	return nil
+}
+
+// toolCachePath is where server inventories are cached for `list`.
+func toolCachePath() string {
+	return filepath.Join(cacheDir(), "tools.json")
+}
+
+func cacheInventory(server config.MCPServer, inv *probe.Inventory) error {
+	return probe.UpdateCache(toolCachePath(), func(cache *probe.Cache) {
+		cache.Store(server, inv, time.Now())
+	})
+}
+
+func inventoryTable(inv *probe.Inventory) table {
+	t := table{header: []string{"KIND", "NAME", "DESCRIPTION"}}
+	for _, tool := range inv.Tools {
+		t.rows = append(t.rows, []string{"tool", tool.Name, orDash(firstLine(tool.Description))})
+	}
+	for _, p := range inv.Prompts {
+		t.rows = append(t.rows, []string{"prompt", p.Name, orDash(firstLine(p.Description))})
+	}
+	for _, r := range inv.Resources {
+		t.rows = append(t.rows, []string{"resource", r.URI, orDash(firstLine(r.Description))})
+	}
+	return t
+}
+
+func printInventory(server config.MCPServer, inv *probe.Inventory) {
+	fmt.Printf("Server: %s (%s)\n", server.Name, server.Source)
+	fmt.Printf("Reports: %s, protocol %s\n", describeInventory(inv), inv.ProtocolVersion)
+	fmt.Printf("─────────────────────────────────\n")
+
+	fmt.Printf("\nTools (%d):\n", len(inv.Tools))
+	for _, tool := range inv.Tools {
+		fmt.Printf("  %s%s\n", tool.Name, descriptionSuffix(tool.Description))
+		for _, param := range schemaParams(tool.InputSchema) {
+			fmt.Printf("      %s\n", param)
+		}
+	}
+
+	if len(inv.Prompts) > 0 {
+		fmt.Printf("\nPrompts (%d):\n", len(inv.Prompts))
+		for _, p := range inv.Prompts {
+			fmt.Printf("  %s%s\n", p.Name, descriptionSuffix(p.Description))
+			for _, a := range p.Arguments {
+				arg := a.Name
+				if a.Required {
+					arg += " (required)"
+				}
+				fmt.Printf("      %s%s\n", arg, descriptionSuffix(a.Description))
+			}
+		}
+	}
+
+	if len(inv.Resources) > 0 {
+		fmt.Printf("\nResources (%d):\n", len(inv.Resources))
+		for _, r := range inv.Resources {
+			label := r.Name
+			if r.MIMEType != "" {
+				label += " (" + r.MIMEType + ")"
+			}
+			fmt.Printf("  %s  %s%s\n", r.URI, label, descriptionSuffix(r.Description))
+		}
+	}
+}
+
+func describeInventory(inv *probe.Inventory) string {
+	name := orDash(inv.Server)
+	if inv.Version != "" {
+		name += " " + inv.Version
+	}
+	return name
+}
+
+func descriptionSuffix(description string) string {
+	if line := firstLine(description); line != "" {
+		return " — " + line
+	}
+	return ""
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(line)
+}
+
+// schemaParams summarizes the top-level properties of a JSON Schema, e.g.
+// "query (string, required) — Search text".
+func schemaParams(schema json.RawMessage) []string {
+	var s struct {
+		Properties map[string]struct {
+			Type        any    `json:"type"`
+			Description string `json:"description"`
+		} `json:"properties"`
+		Required []string `json:"required"`
+	}
+	if err := json.Unmarshal(schema, &s); err != nil {
+		return nil
+	}
+
+	required := make(map[string]bool, len(s.Required))
+	for _, r := range s.Required {
+		required[r] = true
+	}
+	names := make([]string, 0, len(s.Properties))
+	for n := range s.Properties {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	params := make([]string, 0, len(names))
+	for _, n := range names {
+		prop := s.Properties[n]
+		attrs := []string{}
+		if prop.Type != nil {
+			attrs = append(attrs, fmt.Sprint(prop.Type))
+		}
+		if required[n] {
+			attrs = append(attrs, "required")
+		}
+		param := n
+		if len(attrs) > 0 {
+			param += " (" + strings.Join(attrs, ", ") + ")"
+		}
+		params = append(params, param+descriptionSuffix(prop.Description))
+	}
+	return params
+}
@@ -0,0 +1,770 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/config"
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/infrastructure/npm"
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/lockfile"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+func newUICmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "ui",
+		Short: "Browse and manage servers and plugins interactively",
+		Long: `Open a terminal UI listing every MCP server and plugin with its scope,
+status and health. Changes go through the same code as the equivalent
+commands, so backups, lockfile refreshes and policies apply as usual.
+
+Keys:
+  ↑/↓ j/k    move
+  enter      details (credentials masked)
+  space      enable or disable the selected plugin, or the plugin that
+             provides the selected server
+  d          remove the selected server (asks first)
+  h / H      health check the selected server / all servers (server status --health)
+  p          start the selected command server for a handshake (--probe)
+  /          filter the list
+  s          search npm
+  r          reload
+  q          quit`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !term.IsTerminal(int(os.Stdout.Fd())) || !term.IsTerminal(int(os.Stdin.Fd())) {
+				return fmt.Errorf("ui needs a terminal; use 'mcp-plugin list' or 'mcp-plugin server status' in scripts")
+			}
+			model := newUIModel(cmd.Context())
+			defer func(restore func(string, ...any)) { warnf = restore }(warnf)
+			warnf = model.warnings.add
+			_, err := tea.NewProgram(model, tea.WithAltScreen(), tea.WithContext(cmd.Context())).Run()
+			if errors.Is(err, tea.ErrProgramKilled) {
+				return interrupted(cmd.Context())
+			}
+			return err
+		},
+	}
+}
+
+// uiRow is a server or a plugin in the list.
+type uiRow struct {
+	server config.MCPServer // set for servers
+	plugin *config.Plugin   // set for plugins
+}
+
+func (r uiRow) isPlugin() bool { return r.plugin != nil }
+
+func (r uiRow) name() string {
+	if r.isPlugin() {
+		return r.plugin.ID
+	}
+	return r.server.Name
+}
+
+func (r uiRow) scope() string {
+	if r.isPlugin() {
+		return "plugin"
+	}
+	return r.server.Source
+}
+
+func (r uiRow) status() string {
+	if r.isPlugin() {
+		return pluginStatus(*r.plugin)
+	}
+	return serverStatus(r.server)
+}
+
+func (r uiRow) target() string {
+	if r.isPlugin() {
+		return strings.Join(r.plugin.Servers, ", ")
+	}
+	return serverTarget(r.server)
+}
+
+// key identifies a row across reloads.
+func (r uiRow) key() string {
+	if r.isPlugin() {
+		return "plugin/" + r.plugin.ID
+	}
+	return r.server.Source + "/" + r.server.Path + "/" + r.server.Name
+}
+
+type uiMode int
+
+const (
+	uiList uiMode = iota
+	uiDetail
+	uiConfirmRemove
+	uiFilter
+	uiSearchInput
+	uiSearchResults
+)
+
+type uiModel struct {
+	ctx  context.Context
+	mode uiMode
+
+	rows     []uiRow
+	cursor   int
+	filter   string
+	health   map[string]ValidationResult
+	checking map[string]bool
+
+	input        textinput.Model
+	searchQuery  string
+	results      []npm.PackageObject
+	resultCursor int
+
+	message  string
+	failed   bool
+	warnings *uiWarnings
+	width    int
+	height   int
+}
+
+// uiWarnings collects what warnf reports while the ui runs, for the message
+// line.
+type uiWarnings struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (w *uiWarnings) add(format string, args ...any) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.lines = append(w.lines, fmt.Sprintf(format, args...))
+}
+
+// take returns the warnings collected since the last call.
+func (w *uiWarnings) take() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	lines := w.lines
+	w.lines = nil
+	return lines
+}
+
+// Messages sent back by commands running off the UI goroutine.
+type (
+	uiLoadedMsg struct {
+		rows []uiRow
+		err  error
+	}
+	uiHealthMsg struct {
+		results map[string]ValidationResult // by uiRow.key
+	}
+	uiDoneMsg struct {
+		message string
+		err     error
+	}
+	uiSearchMsg struct {
+		query   string
+		results []npm.PackageObject
+		err     error
+	}
+)
+
+var (
+	uiTitleStyle    = lipgloss.NewStyle().Bold(true)
+	uiHeaderStyle   = lipgloss.NewStyle().Bold(true).Underline(true)
+	uiSelectedStyle = lipgloss.NewStyle().Reverse(true)
+	uiHelpStyle     = lipgloss.NewStyle().Faint(true)
+	uiErrorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	uiOKStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+)
+
+func newUIModel(ctx context.Context) uiModel {
+	input := textinput.New()
+	input.CharLimit = 200
+	return uiModel{
+		ctx:      ctx,
+		health:   map[string]ValidationResult{},
+		checking: map[string]bool{},
+		warnings: &uiWarnings{},
+		input:    input,
+		width:    100,
+		height:   24,
+	}
+}
+
+func (m uiModel) Init() tea.Cmd {
+	return loadUIRows
+}
+
+// loadUIRows reads the servers of every scope and the plugins in
+// settings.json and the plugin cache.
+func loadUIRows() tea.Msg {
+	reader := newReader()
+	servers, err := reader.ListMCPServers()
+	if err != nil {
+		return uiLoadedMsg{err: fmt.Errorf("failed to list servers: %w", err)}
+	}
+	rows := make([]uiRow, 0, len(servers))
+	for _, s := range servers {
+		rows = append(rows, uiRow{server: s})
+	}
+
+	plugins, err := reader.ListPlugins()
+	if err != nil {
+		return uiLoadedMsg{rows: rows, err: err}
+	}
+	for i := range plugins {
+		rows = append(rows, uiRow{plugin: &plugins[i]})
+	}
+	return uiLoadedMsg{rows: rows}
+}
+
+func (m uiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+
+	case uiLoadedMsg:
+		m.rows = msg.rows
+		m.cursor = min(m.cursor, max(len(m.visible())-1, 0))
+		if msg.err != nil {
+			m.setMessage(msg.err.Error(), true)
+		}
+		return m, nil
+
+	case uiHealthMsg:
+		for key, result := range msg.results {
+			delete(m.checking, key)
+			m.health[key] = result
+		}
+		m.noteWarnings()
+		return m, nil
+
+	case uiDoneMsg:
+		if msg.err != nil {
+			m.setMessage(msg.err.Error(), true)
+		} else {
+			m.setMessage(msg.message, false)
+		}
+		m.noteWarnings()
+		return m, loadUIRows
+
+	case uiSearchMsg:
+		if msg.query != m.searchQuery {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.mode = uiList
+			m.setMessage(fmt.Sprintf("search failed: %v", msg.err), true)
+		} else {
+			m.results, m.resultCursor = msg.results, 0
+			m.setMessage(fmt.Sprintf("%d package(s) for '%s'", len(msg.results), msg.query), false)
+		}
+		m.noteWarnings()
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		switch m.mode {
+		case uiFilter, uiSearchInput:
+			return m.updateInput(msg)
+		case uiConfirmRemove:
+			return m.updateConfirm(msg)
+		case uiDetail:
+			if k := msg.String(); k == "esc" || k == "enter" || k == "q" {
+				m.mode = uiList
+			}
+			return m, nil
+		case uiSearchResults:
+			return m.updateResults(msg)
+		default:
+			return m.updateList(msg)
+		}
+	}
+	return m, nil
+}
+
+func (m *uiModel) setMessage(text string, failed bool) {
+	m.message, m.failed = text, failed
+}
+
+// noteWarnings appends the warnings reported while a command ran to the
+// message line.
+func (m *uiModel) noteWarnings() {
+	if lines := m.warnings.take(); len(lines) > 0 {
+		m.setMessage(strings.TrimSpace(m.message+" Warning: "+strings.Join(lines, "; ")), true)
+	}
+}
+
+// visible returns the rows matching the filter.
+func (m uiModel) visible() []uiRow {
+	if m.filter == "" {
+		return m.rows
+	}
+	q := strings.ToLower(m.filter)
+	var rows []uiRow
+	for _, r := range m.rows {
+		if strings.Contains(strings.ToLower(r.name()+" "+r.scope()+" "+r.target()), q) {
+			rows = append(rows, r)
+		}
+	}
+	return rows
+}
+
+func (m uiModel) selected() (uiRow, bool) {
+	rows := m.visible()
+	if m.cursor < 0 || m.cursor >= len(rows) {
+		return uiRow{}, false
+	}
+	return rows[m.cursor], true
+}
+
+func (m uiModel) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	row, ok := m.selected()
+	switch msg.String() {
+	case "q", "esc":
+		if msg.String() == "esc" && m.filter != "" {
+			m.filter, m.cursor = "", 0
+			return m, nil
+		}
+		return m, tea.Quit
+	case "up", "k":
+		m.cursor = max(m.cursor-1, 0)
+	case "down", "j":
+		m.cursor = min(m.cursor+1, max(len(m.visible())-1, 0))
+	case "home", "g":
+		m.cursor = 0
+	case "end", "G":
+		m.cursor = max(len(m.visible())-1, 0)
+	case "r":
+		m.setMessage("Reloaded.", false)
+		return m, loadUIRows
+	case "enter":
+		if ok {
+			m.mode = uiDetail
+		}
+	case " ":
+		if !ok {
+			return m, nil
+		}
+		switch {
+		case row.isPlugin():
+			return m, togglePlugin(row.plugin.ID, !row.plugin.Enabled)
+		case row.server.Plugin != "":
+			return m, togglePlugin(row.server.Plugin, !row.server.Enabled)
+		default:
+			m.setMessage("Servers outside plugins are turned on and off with /mcp in Claude Code; remove the server with d instead.", true)
+			return m, nil
+		}
+	case "d":
+		if !ok {
+			return m, nil
+		}
+		if row.isPlugin() {
+			m.setMessage("Plugins cannot be removed here; disable the plugin with space instead.", true)
+			return m, nil
+		}
+		if row.server.Plugin != "" {
+			m.setMessage(fmt.Sprintf("'%s' comes with plugin %s; disable the plugin with space instead.", row.server.Name, row.server.Plugin), true)
+			return m, nil
+		}
+		m.mode = uiConfirmRemove
+	case "h", "p":
+		if !ok || row.isPlugin() {
+			return m, nil
+		}
+		m.checking[row.key()] = true
+		return m, m.checkHealth([]uiRow{row}, msg.String() == "p")
+	case "H":
+		var rows []uiRow
+		for _, r := range m.rows {
+			if !r.isPlugin() {
+				m.checking[r.key()] = true
+				rows = append(rows, r)
+			}
+		}
+		return m, m.checkHealth(rows, false)
+	case "/":
+		m.mode = uiFilter
+		m.input.Prompt, m.input.Placeholder = "Filter: ", "name, scope or target"
+		m.input.SetValue(m.filter)
+		return m, m.input.Focus()
+	case "s":
+		m.mode = uiSearchInput
+		m.input.Prompt, m.input.Placeholder = "Search npm: ", "e.g. kubernetes"
+		m.input.SetValue(m.searchQuery)
+		return m, m.input.Focus()
+	}
+	return m, nil
+}
+
+func (m uiModel) updateInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		if m.mode == uiFilter {
+			m.filter = ""
+		}
+		m.mode = uiList
+		m.input.Blur()
+		return m, nil
+	case "enter":
+		m.input.Blur()
+		value := strings.TrimSpace(m.input.Value())
+		if m.mode == uiFilter {
+			m.mode = uiList
+			return m, nil
+		}
+		if value == "" {
+			m.mode = uiList
+			return m, nil
+		}
+		m.mode, m.searchQuery, m.results = uiSearchResults, value, nil
+		m.setMessage(fmt.Sprintf("Searching npm for '%s'...", value), false)
+		return m, searchNpm(m.ctx, value)
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if m.mode == uiFilter {
+		m.filter, m.cursor = m.input.Value(), 0
+	}
+	return m, cmd
+}
+
+func (m uiModel) updateConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.mode = uiList
+	row, ok := m.selected()
+	if !ok || (msg.String() != "y" && msg.String() != "Y") {
+		m.setMessage("Nothing removed.", false)
+		return m, nil
+	}
+	return m, m.removeServer(row.server)
+}
+
+func (m uiModel) updateResults(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		m.mode = uiList
+		m.setMessage("", false)
+	case "up", "k":
+		m.resultCursor = max(m.resultCursor-1, 0)
+	case "down", "j":
+		m.resultCursor = min(m.resultCursor+1, max(len(m.results)-1, 0))
+	case "s", "/":
+		m.mode = uiSearchInput
+		m.input.SetValue(m.searchQuery)
+		return m, m.input.Focus()
+	case "enter":
+		if m.resultCursor < len(m.results) {
+			pkg := m.results[m.resultCursor].Package
+			m.setMessage(fmt.Sprintf("Install with: mcp-plugin install <name> %s   (check first: mcp-plugin verify %s)", pkg.Name, pkg.Name), false)
+		}
+	}
+	return m, nil
+}
+
+// togglePlugin enables or disables a plugin, as `enable` and `disable` do.
+func togglePlugin(id string, enabled bool) tea.Cmd {
+	return func() tea.Msg {
+		if err := newWriter().SetPluginEnabled(id, enabled); err != nil {
+			return uiDoneMsg{err: fmt.Errorf("failed to update plugin: %w", err)}
+		}
+		state := statusDisabled
+		if enabled {
+			state = statusEnabled
+		}
+		return uiDoneMsg{message: fmt.Sprintf("Plugin '%s' %s. Restart Claude Code for changes to take effect.", id, state)}
+	}
+}
+
+// removeServer removes a server from its scope, as `remove` does, and
+// refreshes the lockfile entry quietly.
+func (m uiModel) removeServer(server config.MCPServer) tea.Cmd {
+	ctx := m.ctx
+	return func() tea.Msg {
+		writer, err := scopedWriter(server.Source)
+		if err != nil {
+			return uiDoneMsg{err: err}
+		}
+		if err := writer.RemoveMCPServer(server.Name); err != nil {
+			return uiDoneMsg{err: fmt.Errorf("failed to remove server: %w", err)}
+		}
+		message := fmt.Sprintf("MCP server '%s' removed from %s scope.", server.Name, writer.Scope())
+		synced, err := syncLockfile(ctx, lockfile.Key(string(writer.Scope()), server.Name))
+		switch {
+		case err != nil:
+			return uiDoneMsg{err: fmt.Errorf("%s %w", message, err)}
+		case synced:
+			message += " Updated " + lockfile.FileName + "."
+		}
+		return uiDoneMsg{message: message}
+	}
+}
+
+// checkHealth runs the `server status --health` check, or with probe the
+// `--probe` handshake, on servers, as many at once and within the same
+// deadline as `server status` by default.
+func (m uiModel) checkHealth(rows []uiRow, probe bool) tea.Cmd {
+	parent := m.ctx
+	return func() tea.Msg {
+		ctx, cancel := checkFlags{deadline: defaultCheckDeadline}.withDeadline(parent)
+		defer cancel()
+		results := runChecks(ctx, rows, defaultCheckConcurrency, func(ctx context.Context, row uiRow) ValidationResult {
+			result, _ := checkServerHealth(ctx, row.server, healthOptions{enabled: true, probe: probe})
+			return result
+		})
+		msg := uiHealthMsg{results: make(map[string]ValidationResult, len(rows))}
+		for i, row := range rows {
+			msg.results[row.key()] = results[i]
+		}
+		return msg
+	}
+}
+
+func searchNpm(ctx context.Context, query string) tea.Cmd {
+	return func() tea.Msg {
+		results, err := newNpmClient(npmFlags{}).Search(ctx, query, 20)
+		if err != nil {
+			return uiSearchMsg{query: query, err: err}
+		}
+		return uiSearchMsg{query: query, results: results.Objects}
+	}
+}
+
+func (m uiModel) View() string {
+	var b strings.Builder
+	b.WriteString(uiTitleStyle.Render("mcp-plugin") + "  " + m.summary() + "\n\n")
+
+	var body []string
+	switch m.mode {
+	case uiDetail:
+		body = m.detailLines()
+	case uiSearchInput, uiSearchResults:
+		body = m.resultLines()
+	default:
+		body = m.listLines()
+	}
+	for _, line := range body {
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString("\n")
+	switch m.mode {
+	case uiFilter, uiSearchInput:
+		b.WriteString(m.input.View() + "\n")
+	case uiConfirmRemove:
+		row, _ := m.selected()
+		b.WriteString(fmt.Sprintf("Remove server '%s' from %s scope? (y/N) ", row.server.Name, row.server.Source) + "\n")
+	default:
+		if m.message != "" {
+			style := uiOKStyle
+			if m.failed {
+				style = uiErrorStyle
+			}
+			b.WriteString(style.Render(fit(m.message, m.width)) + "\n")
+		} else if m.filter != "" {
+			b.WriteString(fmt.Sprintf("Filter: %s (esc clears)\n", m.filter))
+		} else {
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString(uiHelpStyle.Render(fit(m.help(), m.width)))
+	return b.String()
+}
+
+func (m uiModel) summary() string {
+	servers, plugins := 0, 0
+	for _, r := range m.rows {
+		if r.isPlugin() {
+			plugins++
+		} else {
+			servers++
+		}
+	}
+	return fmt.Sprintf("%d server(s), %d plugin(s)", servers, plugins)
+}
+
+func (m uiModel) help() string {
+	switch m.mode {
+	case uiDetail:
+		return "esc back"
+	case uiSearchResults:
+		return "↑/↓ move  enter install hint  s new search  esc back"
+	case uiFilter, uiSearchInput:
+		return "enter confirm  esc cancel"
+	default:
+		return "↑/↓ move  enter details  space toggle plugin  d remove  h/H health  p probe  / filter  s search npm  r reload  q quit"
+	}
+}
+
+// listLines renders the table, scrolled to keep the cursor in view.
+func (m uiModel) listLines() []string {
+	rows := m.visible()
+	if len(rows) == 0 {
+		if m.filter != "" {
+			return []string{"No servers or plugins match the filter."}
+		}
+		return []string{"No MCP servers or plugins found."}
+	}
+
+	cols := []string{"NAME", "SCOPE", "STATUS", "HEALTH", "TARGET"}
+	cells := make([][]string, len(rows))
+	widths := []int{len(cols[0]), len(cols[1]), len(cols[2]), 28, 0}
+	for i, r := range rows {
+		cells[i] = []string{r.name(), r.scope(), r.status(), m.healthCell(r), r.target()}
+		for c := 0; c < 3; c++ {
+			widths[c] = max(widths[c], lipgloss.Width(cells[i][c]))
+		}
+	}
+	widths[0] = min(widths[0], 40)
+	used := widths[0] + widths[1] + widths[2] + widths[3] + 8
+	widths[4] = max(m.width-used, 10)
+
+	lines := []string{uiHeaderStyle.Render(formatRow(cols, widths))}
+	first, last := scrollWindow(m.cursor, len(rows), max(m.height-8, 3))
+	for i := first; i < last; i++ {
+		line := formatRow(cells[i], widths)
+		if i == m.cursor {
+			line = uiSelectedStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	if first > 0 || last < len(rows) {
+		lines = append(lines, uiHelpStyle.Render(fmt.Sprintf("%d-%d of %d", first+1, last, len(rows))))
+	}
+	return lines
+}
+
+func (m uiModel) healthCell(r uiRow) string {
+	if r.isPlugin() {
+		return ""
+	}
+	if m.checking[r.key()] {
+		return "checking..."
+	}
+	if h, ok := m.health[r.key()]; ok {
+		return formatHealth(h)
+	}
+	return "-"
+}
+
+// detailLines shows the selected row the way `server info` does, with
+// credentials masked.
+func (m uiModel) detailLines() []string {
+	row, ok := m.selected()
+	if !ok {
+		return nil
+	}
+	if row.isPlugin() {
+		lines := []string{"Plugin:  " + row.plugin.ID, "Status:  " + row.status()}
+		if row.plugin.Path != "" {
+			lines = append(lines, "Source:  "+row.plugin.Path)
+		} else if !row.plugin.Installed {
+			lines = append(lines, "Not in the plugin cache")
+		}
+		for _, r := range m.rows {
+			if !r.isPlugin() && r.server.Plugin == row.plugin.ID {
+				lines = append(lines, "Server:  "+r.server.Name+"  "+serverTarget(r.server))
+			}
+		}
+		return lines
+	}
+
+	s := maskServer(row.server)
+	lines := []string{
+		"Server: " + s.Name,
+		"Status: " + serverStatus(s),
+		"Type:   " + s.Type,
+		"Scope:  " + s.Source,
+		"Source: " + s.Path,
+	}
+	if s.Plugin != "" {
+		lines = append(lines, "Plugin: "+s.Plugin)
+	}
+	if s.Command != "" {
+		lines = append(lines, "Command: "+s.Command)
+		if len(s.Args) > 0 {
+			lines = append(lines, "Args:    "+strings.Join(s.Args, " "))
+		}
+	}
+	if s.URL != "" {
+		lines = append(lines, "URL:     "+s.URL)
+	}
+	for _, k := range slices.Sorted(maps.Keys(s.Headers)) {
+		lines = append(lines, fmt.Sprintf("Header:  %s: %s", k, s.Headers[k]))
+	}
+	for _, k := range slices.Sorted(maps.Keys(s.Env)) {
+		lines = append(lines, fmt.Sprintf("Env:     %s=%s", k, s.Env[k]))
+	}
+	if h, ok := m.health[row.key()]; ok {
+		lines = append(lines, "", "Health: "+formatHealth(h))
+	}
+	for i, line := range lines {
+		lines[i] = fit(line, m.width)
+	}
+	return lines
+}
+
+func (m uiModel) resultLines() []string {
+	if m.mode == uiSearchInput || m.results == nil {
+		return []string{"Search npm for MCP packages, as 'mcp-plugin search' does."}
+	}
+	if len(m.results) == 0 {
+		return []string{"No packages found."}
+	}
+	var lines []string
+	first, last := scrollWindow(m.resultCursor, len(m.results), max((m.height-8)/2, 2))
+	for i := first; i < last; i++ {
+		pkg := m.results[i].Package
+		line := fit(fmt.Sprintf("%s@%s", pkg.Name, pkg.Version), m.width)
+		if i == m.resultCursor {
+			line = uiSelectedStyle.Render(line)
+		}
+		lines = append(lines, line, uiHelpStyle.Render(fit("    "+pkg.Description, m.width)))
+	}
+	return lines
+}
+
+// scrollWindow returns the range of n items to show in height lines so the
+// cursor stays visible.
+func scrollWindow(cursor, n, height int) (int, int) {
+	if n <= height {
+		return 0, n
+	}
+	first := min(max(cursor-height/2, 0), n-height)
+	return first, first + height
+}
+
+func formatRow(cells []string, widths []int) string {
+	parts := make([]string, len(cells))
+	for i, c := range cells {
+		c = fit(c, widths[i])
+		parts[i] = c + strings.Repeat(" ", max(widths[i]-lipgloss.Width(c), 0))
+	}
+	return strings.TrimRight(strings.Join(parts, "  "), " ")
+}
+
+// fit cuts s to width display columns.
+func fit(s string, width int) string {
+	if width <= 0 || lipgloss.Width(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+1 > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package command
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestUIModel_WarningsGoToMessageLine(t *testing.T) {
+	m := newUIModel(context.Background())
+	m.checking["a"], m.checking["b"] = true, true
+	m.warnings.add("ignoring .npmrc: %s", "bad line 3")
+
+	model, _ := m.Update(uiHealthMsg{results: map[string]ValidationResult{"a": {Status: "pass"}, "b": {Status: "fail"}}})
+	got := model.(uiModel)
+	if len(got.checking) != 0 || len(got.health) != 2 {
+		t.Errorf("checking = %v, health = %v; want both results recorded", got.checking, got.health)
+	}
+	if !got.failed || !strings.Contains(got.message, "ignoring .npmrc: bad line 3") {
+		t.Errorf("message = %q, want the warning", got.message)
+	}
+	if lines := got.warnings.take(); lines != nil {
+		t.Errorf("warnings left = %v, want them taken", lines)
+	}
+}
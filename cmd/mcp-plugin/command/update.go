@@ -4,19 +4,24 @@
 package command
 
 import (
+	"context"
 	"fmt"
-	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/config"
 	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/infrastructure/npm"
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/infrastructure/pypi"
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/lockfile"
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/provenance"
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/versions"
 	"github.com/spf13/cobra"
 )
 
 func newUpdateCmd() *cobra.Command {
-	var all bool
-	var dryRun bool
-	var force bool
+	opts := updateOptions{verify: &verifyFlags{}}
+	var policy string
 
 	cmd := &cobra.Command{
 		Use:   "update [server]",
@@ -24,10 +29,29 @@ func newUpdateCmd() *cobra.Command {
 		Long: `Update MCP servers to their latest versions.
 
 This command checks for updates for npm-based MCP servers (npx command)
-and updates them to the latest version from the npm registry.
-
-Note: Only servers using 'npx' command can be updated automatically.
-HTTP-based and uvx-based servers need manual updates.
+and Python-based ones (uvx command), and pins them to the latest version
+from the npm registry or PyPI. uvx packages keep the form they were written
+in: 'uvx pkg==1.0.0', 'uvx pkg@1.0.0' or 'uvx --from pkg==1.0.0 cmd'.
+Unpinned uvx packages are pinned as 'pkg@x.y.z'.
+
+--policy limits how far a server moves from the version it resolves to
+now: patch stays on the same major.minor, minor on the same major, and major
+(the default) follows the 'latest' dist-tag. Pre-releases are only chosen
+for servers already on one, and no server is moved to an older version.
+--to picks an explicit target instead: a version, a dist-tag such as 'next',
+or a semver range such as '^1.4' (the highest matching version).
+
+Each target version goes through the supply-chain checks of 'mcp-plugin
+verify' (recently published, new maintainers, install scripts, ...). The
+findings are listed under each server and in the RISKS column of --dry-run
+output; with --strict, servers with findings are not updated.
+
+Note: Only servers using the 'npx' or 'uvx' command can be updated
+automatically. HTTP-based servers need manual updates. Servers provided by
+plugins are managed by the plugin and are skipped.
+
+Each server is updated in the scope it is configured in; use --scope to
+restrict the update to one scope.
 
 Examples:
   # Check for updates on all servers
@@ -40,83 +64,205 @@ Examples:
   mcp-plugin update --all
 
   # Force update even if already latest
-  mcp-plugin update context7 --force`,
+  mcp-plugin update context7 --force
+
+  # Take bug fixes only
+  mcp-plugin update --all --policy patch
+
+  # Move one server to an explicit version, tag or range
+  mcp-plugin update context7 --to '^1.0'
+
+  # Update only the servers in the repository's .mcp.json
+  mcp-plugin update --all --scope project`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if !all && len(args) == 0 {
+			if !opts.all && len(args) == 0 {
 				return fmt.Errorf("specify a server name or use --all")
 			}
-
-			var serverName string
 			if len(args) > 0 {
-				serverName = args[0]
+				opts.serverName = args[0]
 			}
 
-			return runUpdate(serverName, all, dryRun, force)
+			var err error
+			if opts.target.policy, err = versions.ParsePolicy(policy); err != nil {
+				return err
+			}
+			return runUpdate(cmd.Context(), opts)
 		},
 	}
 
-	cmd.Flags().BoolVar(&all, "all", false, "Update all updatable servers")
-	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be updated without making changes")
-	cmd.Flags().BoolVar(&force, "force", false, "Force update even if already at latest version")
+	cmd.Flags().BoolVar(&opts.all, "all", false, "Update all updatable servers")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Show what would be updated without making changes")
+	cmd.Flags().BoolVar(&opts.force, "force", false, "Force update even if already at latest version")
+	cmd.Flags().StringVarP(&opts.scope, "scope", "s", "", "Only update servers from this scope (user, project, local)")
+	cmd.Flags().StringVar(&opts.target.to, "to", "", "Target version, dist-tag or semver range")
+	cmd.Flags().StringVar(&policy, "policy", string(versions.Major), "How far to update: patch, minor or major")
+	cmd.MarkFlagsMutuallyExclusive("to", "policy")
+	addVerifyFlags(cmd, opts.verify)
+	cmd.MarkFlagsMutuallyExclusive("force", "strict")
+	addNpmFlags(cmd, &opts.npm)
 
 	return cmd
 }
 
 // ServerUpdate represents an update check result.
 type ServerUpdate struct {
-	Name           string
-	PackageName    string
-	CurrentVersion string
-	LatestVersion  string
-	CanUpdate      bool
-	Reason         string
+	Name           string `json:"name"`
+	Scope          string `json:"scope"`
+	Registry       string `json:"registry,omitempty"` // npm or pypi
+	PackageName    string `json:"package_name,omitempty"`
+	CurrentVersion string `json:"current_version,omitempty"`
+	TargetVersion  string `json:"target_version,omitempty"`
+	LatestVersion  string `json:"latest_version,omitempty"`
+	CanUpdate      bool   `json:"can_update"`
+	Reason         string `json:"reason,omitempty"`
+	// Risks are the supply-chain findings for the target version; Blocked
+	// is set when --strict kept the server from updating because of them.
+	Risks   []provenance.Finding `json:"risks,omitempty"`
+	Blocked bool                 `json:"blocked,omitempty"`
+}
+
+// updateOptions are the flags of `update` and `pin`.
+type updateOptions struct {
+	serverName string
+	scope      string
+	all        bool
+	dryRun     bool
+	force      bool
+	npm        npmFlags
+	target     updateTarget
+	verify     *verifyFlags // nil skips the supply-chain checks
+}
+
+// updateTarget decides which version a server moves to.
+type updateTarget struct {
+	to     string          // --to: a version, dist-tag or range
+	policy versions.Policy // used when to is empty
+	pin    bool            // `pin`: the version the current spec resolves to
+}
+
+func (t updateTarget) choose(current string, rel *release) (string, error) {
+	switch {
+	case t.to != "":
+		return versions.Resolve(t.to, rel.versions, rel.tags)
+	case t.pin:
+		return versions.Resolve(current, rel.versions, rel.tags)
+	default:
+		return versions.Upgrade(current, t.policy, rel.versions, rel.tags)
+	}
+}
+
+// verbs name the run and what it did to a server in its messages.
+func (t updateTarget) verbs() (run, done string) {
+	if t.pin {
+		return "Pin", "pinned"
+	}
+	return "Update", "updated"
 }
 
-func runUpdate(serverName string, all, dryRun, force bool) error {
-	reader := config.NewReader()
-	writer := config.NewWriter()
-	npmClient := npm.NewClient()
+func runUpdate(ctx context.Context, opts updateOptions) error {
+	if !textOutput() && !opts.dryRun {
+		return fmt.Errorf("--output %s requires --dry-run", outputFormat)
+	}
+
+	reader := newReader()
+	sources := newRegistries(opts.npm)
 
 	servers, err := reader.ListMCPServers()
 	if err != nil {
 		return fmt.Errorf("failed to list servers: %w", err)
 	}
 
-	if len(servers) == 0 {
+	if len(servers) == 0 && textOutput() {
 		fmt.Println("No MCP servers configured.")
 		return nil
 	}
 
-	toCheck := filterServersToUpdate(servers, serverName, all)
+	servers, err = filterByScope(servers, opts.scope)
+	if err != nil {
+		return err
+	}
+
+	toCheck := filterServersToUpdate(servers, opts.serverName, opts.all)
 	if len(toCheck) == 0 {
-		if serverName != "" {
-			return fmt.Errorf("server '%s' not found", serverName)
+		if opts.serverName != "" {
+			return fmt.Errorf("server '%s' not found", opts.serverName)
+		}
+		if textOutput() {
+			fmt.Println("No servers to update.")
+			return nil
 		}
-		fmt.Println("No servers to update.")
-		return nil
 	}
 
-	fmt.Println("Checking for updates...")
-	fmt.Println()
+	if textOutput() {
+		if opts.target.pin {
+			fmt.Println("Resolving versions...")
+		} else {
+			fmt.Println("Checking for updates...")
+		}
+		fmt.Println()
+	}
 
-	updates, updatable := collectServerUpdates(toCheck, npmClient)
+	updates, updatable := collectServerUpdates(ctx, toCheck, sources, opts.target, opts.verify)
+
+	if handled, err := render("UpdatePlan", updates, func() table {
+		t := table{header: []string{"NAME", "SCOPE", "PACKAGE", "CURRENT", "TARGET", "LATEST", "UPDATE", "RISKS", "REASON"}}
+		for _, u := range updates {
+			risks := make([]string, 0, len(u.Risks))
+			for _, r := range u.Risks {
+				risks = append(risks, string(r.Kind))
+			}
+			t.rows = append(t.rows, []string{
+				u.Name, u.Scope, orDash(u.PackageName), orDash(u.CurrentVersion), orDash(u.TargetVersion),
+				orDash(u.LatestVersion), strconv.FormatBool(u.CanUpdate), orDash(strings.Join(risks, ",")), orDash(u.Reason),
+			})
+		}
+		return t
+	}); handled {
+		return err
+	}
 
 	fmt.Println()
 
-	if updatable == 0 && !force {
-		fmt.Println("All servers are up to date.")
-		return nil
+	var blocked error
+	if n := countBlocked(updates); n > 0 {
+		blocked = fmt.Errorf("%d server(s) blocked by supply-chain findings (--strict)", n)
 	}
 
-	if dryRun {
-		fmt.Printf("Dry run: %d server(s) would be updated.\n", updatable)
+	if updatable == 0 && !opts.force {
+		if blocked != nil {
+			return blocked
+		}
+		if opts.target.pin {
+			fmt.Println("All servers are already pinned.")
+		} else {
+			fmt.Println("All servers are up to date.")
+		}
 		return nil
 	}
 
-	updated, failed := applyServerUpdates(writer, updates, force)
+	run, done := opts.target.verbs()
+	if opts.dryRun {
+		fmt.Printf("Dry run: %d server(s) would be %s.\n", updatable, done)
+		return blocked
+	}
+
+	updated, failed := applyServerUpdates(updates, opts.force, done)
 	fmt.Println()
-	fmt.Printf("Update complete: %d updated, %d failed\n", updated, failed)
-	return nil
+	fmt.Printf("%s complete: %d %s, %d failed\n", run, len(updated), done, failed)
+	if len(updated) > 0 {
+		reportLockSync(syncLockfile(ctx, updated...))
+	}
+	return blocked
+}
+
+func countBlocked(updates []ServerUpdate) int {
+	n := 0
+	for _, u := range updates {
+		if u.Blocked {
+			n++
+		}
+	}
+	return n
 }
 
 func filterServersToUpdate(servers []config.MCPServer, serverName string, all bool) []config.MCPServer {
@@ -129,13 +275,29 @@ func filterServersToUpdate(servers []config.MCPServer, serverName string, all bo
 	return toCheck
 }
 
-func collectServerUpdates(toCheck []config.MCPServer, npmClient *npm.Client) (updates []ServerUpdate, updatable int) {
+// registries are the package registries that npx and uvx servers come from.
+type registries struct {
+	npm     *npm.Client
+	pypi    *pypi.Client
+	offline bool // PyPI responses are not cached, so PyPI is skipped
+}
+
+func newRegistries(f npmFlags) registries {
+	return registries{npm: newNpmClient(f), pypi: pypi.NewClient(), offline: f.offline}
+}
+
+func collectServerUpdates(ctx context.Context, toCheck []config.MCPServer, sources registries, target updateTarget, verify *verifyFlags) (updates []ServerUpdate, updatable int) {
 	updates = make([]ServerUpdate, 0, len(toCheck))
 
 	for _, server := range toCheck {
-		update := checkServerUpdate(server, npmClient)
+		update := checkServerUpdate(ctx, server, sources, target)
+		if update.CanUpdate && verify != nil {
+			checkUpdateRisks(ctx, &update, sources, *verify)
+		}
 		updates = append(updates, update)
-		printUpdateStatus(update)
+		if textOutput() {
+			printUpdateStatus(update)
+		}
 		if update.CanUpdate {
 			updatable++
 		}
@@ -144,12 +306,21 @@ func collectServerUpdates(toCheck []config.MCPServer, npmClient *npm.Client) (up
 }
 
 func printUpdateStatus(update ServerUpdate) {
+	printUpdateLine(update)
+	printFindings(update.Risks)
+}
+
+func printUpdateLine(update ServerUpdate) {
 	if update.CanUpdate {
+		var latest string
+		if update.LatestVersion != "" && update.LatestVersion != update.TargetVersion {
+			latest = fmt.Sprintf(" (latest %s)", update.LatestVersion)
+		}
 		if update.CurrentVersion != "" {
-			fmt.Printf("📦 %s: %s → %s\n", update.Name, update.CurrentVersion, update.LatestVersion)
+			fmt.Printf("📦 %s: %s → %s%s\n", update.Name, update.CurrentVersion, update.TargetVersion, latest)
 			return
 		}
-		fmt.Printf("📦 %s: (unversioned) → %s\n", update.Name, update.LatestVersion)
+		fmt.Printf("📦 %s: (unversioned) → %s%s\n", update.Name, update.TargetVersion, latest)
 		return
 	}
 	if update.Reason == "up-to-date" {
@@ -159,100 +330,192 @@ func printUpdateStatus(update ServerUpdate) {
 	fmt.Printf("⏭️  %s: %s\n", update.Name, update.Reason)
 }
 
-func applyServerUpdates(writer *config.Writer, updates []ServerUpdate, force bool) (updated, failed int) {
+// applyServerUpdates writes the updates and returns the lockfile.Key of
+// each server it changed.
+func applyServerUpdates(updates []ServerUpdate, force bool, done string) (updated []string, failed int) {
 	for _, update := range updates {
 		if !update.CanUpdate && !force {
 			continue
 		}
-		if update.PackageName == "" {
+		if update.PackageName == "" || update.TargetVersion == "" {
 			continue
 		}
-		if err := applyOneServerUpdate(writer, update); err != nil {
+		if err := applyOneServerUpdate(update); err != nil {
 			fmt.Printf("⚠️  %v\n", err)
 			failed++
 			continue
 		}
-		fmt.Printf("✅ Updated %s to %s\n", update.Name, update.LatestVersion)
-		updated++
+		fmt.Printf("✅ %s %s to %s\n", strings.ToUpper(done[:1])+done[1:], update.Name, update.TargetVersion)
+		updated = append(updated, lockfile.Key(update.Scope, update.Name))
 	}
 	return updated, failed
 }
 
-func applyOneServerUpdate(writer *config.Writer, update ServerUpdate) error {
-	existingServers, err := writer.ListMCPServersGlobal()
+func applyOneServerUpdate(update ServerUpdate) error {
+	writer := newWriter().WithScope(config.Scope(update.Scope))
+
+	existingServers, err := writer.ListMCPServerEntries()
 	if err != nil {
 		return fmt.Errorf("failed to read config for %s: %w", update.Name, err)
 	}
 
 	entry, exists := existingServers[update.Name]
 	if !exists {
-		return fmt.Errorf("server %s not found in global config", update.Name)
+		return fmt.Errorf("server %s not found in %s scope", update.Name, update.Scope)
 	}
 
-	newArgs := updateArgsToLatest(entry.Args, update.PackageName, update.LatestVersion)
-	newEntry := config.MCPServerEntry{
-		Type:    entry.Type,
-		Command: entry.Command,
-		Args:    newArgs,
-		URL:     entry.URL,
-		Headers: entry.Headers,
-		Enabled: entry.Enabled,
+	// Wrapped servers keep the `mcp-plugin exec` prefix; only the package
+	// argument after it changes.
+	command, args := unwrapExec(entry.Command, entry.Args)
+	var pinned []string
+	if command == "uvx" {
+		pkg, ok := parseUVXArgs(args)
+		if !ok {
+			return fmt.Errorf("cannot find the package of %s", update.Name)
+		}
+		pinned = pkg.pinned(args, update.TargetVersion)
+	} else {
+		pinned = pinNpxArgs(args, update.PackageName, update.TargetVersion)
 	}
+	prefix := entry.Args[:len(entry.Args)-len(args)]
+	entry.Args = append(slices.Clip(prefix), pinned...)
 
-	if err := writer.RemoveMCPServer(update.Name); err != nil {
-		return fmt.Errorf("failed to update %s: %w", update.Name, err)
+	policies, err := loadPolicies()
+	if err != nil {
+		return err
+	}
+	if err := checkPolicy(policies, update.Name, writer.Scope(), entry); err != nil {
+		return err
 	}
-	if err := writer.AddMCPServer(update.Name, newEntry); err != nil {
+
+	if err := writer.UpdateMCPServer(update.Name, entry); err != nil {
 		return fmt.Errorf("failed to update %s: %w", update.Name, err)
 	}
 	return nil
 }
 
-func checkServerUpdate(server config.MCPServer, npmClient *npm.Client) ServerUpdate {
+func checkServerUpdate(ctx context.Context, server config.MCPServer, sources registries, target updateTarget) ServerUpdate {
 	update := ServerUpdate{
-		Name: server.Name,
+		Name:  server.Name,
+		Scope: server.Source,
 	}
 
-	// Only npx-based servers can be updated
-	if server.Command != "npx" {
-		if server.Command != "" {
-			update.Reason = fmt.Sprintf("not npm-based (%s)", server.Command)
-		} else {
-			update.Reason = "HTTP-based server"
+	if server.Source == string(config.ScopePlugin) {
+		update.Reason = "managed by plugin"
+		return update
+	}
+
+	// Only npx- and uvx-based servers can be updated
+	var rel *release
+	command, args := unwrapExec(server.Command, server.Args)
+	switch command {
+	case "npx":
+		update.Registry = "npm"
+		rel = checkNpmUpdate(ctx, &update, args, sources.npm)
+	case "uvx":
+		update.Registry = "pypi"
+		rel = checkPyPIUpdate(ctx, &update, args, sources)
+	case "":
+		update.Reason = "HTTP-based server"
+	default:
+		update.Reason = fmt.Sprintf("not npm- or PyPI-based (%s)", command)
+	}
+	if update.Reason != "" {
+		return update
+	}
+
+	update.LatestVersion = rel.tags["latest"]
+	version, err := target.choose(update.CurrentVersion, rel)
+	if err != nil {
+		update.Reason = err.Error()
+		return update
+	}
+	update.TargetVersion = version
+
+	if update.CurrentVersion == version {
+		update.Reason = "up-to-date"
+		if target.pin {
+			update.Reason = "already pinned"
 		}
 		return update
 	}
 
-	packageName, currentVersion := extractPackageInfo(server.Args)
+	update.CanUpdate = true
+	return update
+}
+
+// release is what a registry offers for one package.
+type release struct {
+	versions []string
+	tags     map[string]string // dist-tags; PyPI only has "latest"
+}
+
+func fetchNpmRelease(ctx context.Context, client *npm.Client, name string) (*release, error) {
+	pkg, err := client.GetPackageVersions(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return &release{versions: pkg.VersionList(), tags: pkg.DistTags}, nil
+}
+
+func fetchPyPIRelease(ctx context.Context, client *pypi.Client, name string) (*release, error) {
+	project, err := client.GetProject(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return &release{versions: project.Versions(), tags: map[string]string{"latest": project.LatestVersion()}}, nil
+}
+
+// checkNpmUpdate fills in the package of npx args and returns what the
+// registry offers for it, or sets update.Reason.
+func checkNpmUpdate(ctx context.Context, update *ServerUpdate, args []string, client *npm.Client) *release {
+	packageName, currentVersion := extractPackageInfo(args)
 	if packageName == "" {
 		update.Reason = "cannot determine package name"
-		return update
+		return nil
 	}
 
 	update.PackageName = packageName
 	update.CurrentVersion = currentVersion
 
-	pkgDetail, err := npmClient.GetPackage(packageName)
+	rel, err := fetchNpmRelease(ctx, client, packageName)
 	if err != nil {
 		update.Reason = fmt.Sprintf("npm error: %v", err)
-		return update
+		return nil
 	}
+	return rel
+}
 
-	latestVersion := pkgDetail.LatestVersion()
-	if latestVersion == "" {
-		update.Reason = "no latest version found"
-		return update
+// checkPyPIUpdate is checkNpmUpdate for uvx args.
+func checkPyPIUpdate(ctx context.Context, update *ServerUpdate, args []string, sources registries) *release {
+	pkg, ok := parseUVXArgs(args)
+	if !ok || pkg.name == "" {
+		update.Reason = "cannot determine package name"
+		return nil
 	}
 
-	update.LatestVersion = latestVersion
+	update.PackageName = pkg.name
+	update.CurrentVersion = pkg.version
 
-	if currentVersion == latestVersion {
-		update.Reason = "up-to-date"
-		return update
+	if pkg.direct {
+		update.Reason = "not installed from PyPI"
+		return nil
+	}
+	if pkg.constraint != "" {
+		update.Reason = fmt.Sprintf("version range %s", pkg.constraint)
+		return nil
+	}
+	if sources.offline {
+		update.Reason = "PyPI is not available offline"
+		return nil
 	}
 
-	update.CanUpdate = true
-	return update
+	rel, err := fetchPyPIRelease(ctx, sources.pypi, pkg.name)
+	if err != nil {
+		update.Reason = fmt.Sprintf("pypi error: %v", err)
+		return nil
+	}
+	return rel
 }
 
 // extractPackageInfo extracts package name and version from npx args.
@@ -288,24 +551,18 @@ func extractPackageInfo(args []string) (packageName, version string) {
 	return "", ""
 }
 
-// updateArgsToLatest updates the args to use the latest version.
-func updateArgsToLatest(args []string, packageName, latestVersion string) []string {
-	newArgs := make([]string, len(args))
-	copy(newArgs, args)
-
-	versionPattern := regexp.MustCompile(`@\d+\.\d+\.\d+(-[a-zA-Z0-9.-]+)?$`)
-
+// pinNpxArgs points the package argument of npx args at version, replacing
+// any version, tag or range it had.
+func pinNpxArgs(args []string, packageName, version string) []string {
+	newArgs := slices.Clone(args)
 	for i, arg := range newArgs {
 		if strings.HasPrefix(arg, "-") {
 			continue
 		}
-
-		if strings.Contains(arg, packageName) || arg == packageName {
-			cleanName := versionPattern.ReplaceAllString(arg, "")
-			newArgs[i] = fmt.Sprintf("%s@%s", cleanName, latestVersion)
+		if name, _ := extractPackageInfo([]string{arg}); name == packageName {
+			newArgs[i] = packageName + "@" + version
 			break
 		}
 	}
-
 	return newArgs
 }
@@ -0,0 +1,93 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package command
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// uvxValueFlags are the uvx options that take a separate value, so that value
+// is not mistaken for the package.
+var uvxValueFlags = map[string]bool{
+	"--with": true, "--with-editable": true, "--with-requirements": true,
+	"--python": true, "-p": true,
+	"--index": true, "--default-index": true, "--index-url": true, "-i": true,
+	"--extra-index-url": true, "--find-links": true, "-f": true,
+	"--directory": true, "--project": true, "--config-file": true, "--cache-dir": true,
+}
+
+// uvxPackage is the package a uvx command runs, as written in its args.
+type uvxPackage struct {
+	index  int    // position of the spec in args
+	prefix string // "--from=" when the spec shares an argument with the flag
+	name   string
+	extras string // "[cli]", kept when rewriting
+	sep    string // "==" or "@"; empty when unpinned
+	// version is the pinned version. Other specifiers such as ">=1.0" leave
+	// it empty and set constraint.
+	version    string
+	constraint string
+	// direct is set for URLs and paths, which do not come from PyPI.
+	direct bool
+}
+
+// parseUVXArgs finds the package of `uvx [options] <spec>` and
+// `uvx --from <spec> <command>`, where spec is pkg, pkg==x.y.z or pkg@x.y.z.
+func parseUVXArgs(args []string) (uvxPackage, bool) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--from" && i+1 < len(args):
+			return parseUVXSpec(i+1, "", args[i+1]), true
+		case strings.HasPrefix(arg, "--from="):
+			return parseUVXSpec(i, "--from=", strings.TrimPrefix(arg, "--from=")), true
+		case strings.HasPrefix(arg, "-"):
+			if uvxValueFlags[arg] {
+				i++
+			}
+		default:
+			return parseUVXSpec(i, "", arg), true
+		}
+	}
+	return uvxPackage{}, false
+}
+
+func parseUVXSpec(index int, prefix, spec string) uvxPackage {
+	pkg := uvxPackage{index: index, prefix: prefix, name: spec}
+	if strings.Contains(spec, "://") || strings.Contains(spec, " @ ") || strings.HasPrefix(spec, ".") || filepath.IsAbs(spec) {
+		pkg.direct = true
+		return pkg
+	}
+
+	if name, version, ok := strings.Cut(spec, "=="); ok {
+		pkg.name, pkg.sep, pkg.version = name, "==", version
+	} else if i := strings.IndexAny(spec, "<>~!=;"); i >= 0 {
+		pkg.name, pkg.constraint = spec[:i], spec[i:]
+	} else if name, version, ok := strings.Cut(spec, "@"); ok {
+		pkg.name = name
+		if version != "latest" {
+			pkg.sep, pkg.version = "@", version
+		}
+	}
+
+	if i := strings.Index(pkg.name, "["); i >= 0 {
+		pkg.name, pkg.extras = pkg.name[:i], pkg.name[i:]
+	}
+	pkg.name = strings.TrimSpace(pkg.name)
+	return pkg
+}
+
+// pinned returns args with the package pinned to version, keeping the form
+// it was written in. Unpinned packages get the @ form uvx documents.
+func (p uvxPackage) pinned(args []string, version string) []string {
+	sep := p.sep
+	if sep == "" {
+		sep = "@"
+	}
+	newArgs := make([]string, len(args))
+	copy(newArgs, args)
+	newArgs[p.index] = p.prefix + p.name + p.extras + sep + version
+	return newArgs
+}
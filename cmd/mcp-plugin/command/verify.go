@@ -0,0 +1,345 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package command
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/infrastructure/npm"
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/infrastructure/pypi"
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/provenance"
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/versions"
+	"github.com/spf13/cobra"
+)
+
+// defaultMinAgeDays is how new a version must be to be flagged as recent.
+// Malicious releases are usually caught and unpublished within days.
+const defaultMinAgeDays = 7
+
+func newVerifyCmd() *cobra.Command {
+	var (
+		f        verifyFlags
+		npmf     npmFlags
+		fromPyPI bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "verify <package>[@version]",
+		Short: "Check a package for supply-chain risks",
+		Long: `Check the registry metadata of a package version for supply-chain risks.
+
+'npx -y <pkg>' downloads and runs the package every time Claude Code starts
+the server, so a compromised release runs with the user's privileges. verify
+flags:
+  recent          published within --min-age-days (default 7)
+  maintainers     published by accounts that did not maintain the previous release
+  repository      no source repository linked
+  install-script  runs preinstall/install/postinstall scripts (for PyPI: has
+                  no wheel, so installing runs the sdist's build)
+  deprecated      deprecated on npm, or yanked on PyPI
+  typosquat       name one edit or swap away from a well-known MCP package
+
+The version defaults to the latest dist-tag and may be a tag or range.
+install and update run the same checks; with --strict they refuse packages
+with findings, and so does verify by exiting non-zero.
+
+Examples:
+  mcp-plugin verify @upstash/context7-mcp
+  mcp-plugin verify @modelcontextprotocol/server-github@2025.4.8
+  mcp-plugin verify mcp-server-fetch --pypi`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref := packageRef{registry: "npm"}
+			if fromPyPI {
+				pkg := parseUVXSpec(0, "", args[0])
+				ref = packageRef{registry: "pypi", name: pkg.name, spec: pkg.version}
+			} else {
+				ref.name, ref.spec = extractPackageInfo(args)
+			}
+			return runVerify(cmd.Context(), ref, newRegistries(npmf), f)
+		},
+	}
+
+	cmd.Flags().BoolVar(&fromPyPI, "pypi", false, "Check a Python package on PyPI")
+	addVerifyFlags(cmd, &f)
+	addNpmFlags(cmd, &npmf)
+	return cmd
+}
+
+// verifyFlags are the supply-chain check flags of verify, install and
+// update.
+type verifyFlags struct {
+	strict     bool
+	minAgeDays int
+}
+
+func addVerifyFlags(cmd *cobra.Command, f *verifyFlags) {
+	cmd.Flags().BoolVar(&f.strict, "strict", false, "Refuse packages with supply-chain findings")
+	cmd.Flags().IntVar(&f.minAgeDays, "min-age-days", defaultMinAgeDays, "Flag versions published fewer than this many days ago (0 disables)")
+}
+
+func (f verifyFlags) options() provenance.Options {
+	return provenance.Options{MinAge: time.Duration(f.minAgeDays) * 24 * time.Hour}
+}
+
+// ProvenanceReport is the result of `verify`.
+type ProvenanceReport struct {
+	Registry string               `json:"registry"`
+	Package  string               `json:"package"`
+	Version  string               `json:"version"`
+	Findings []provenance.Finding `json:"findings"`
+}
+
+func runVerify(ctx context.Context, ref packageRef, sources registries, f verifyFlags) error {
+	version, findings, err := checkPackage(ctx, sources, ref, f)
+	if err != nil {
+		return err
+	}
+	report := ProvenanceReport{Registry: ref.registry, Package: ref.name, Version: version, Findings: findings}
+	if report.Findings == nil {
+		report.Findings = []provenance.Finding{}
+	}
+
+	if handled, err := render("ProvenanceReport", report, func() table {
+		t := table{header: []string{"PACKAGE", "VERSION", "CHECK", "FINDING"}}
+		for _, finding := range findings {
+			t.rows = append(t.rows, []string{ref.name, version, string(finding.Kind), finding.Message})
+		}
+		return t
+	}); handled {
+		return strictError(err, f, ref.name, findings)
+	}
+
+	fmt.Printf("🔍 %s@%s (%s)\n\n", ref.name, version, ref.registry)
+	if len(findings) == 0 {
+		fmt.Println("✅ No supply-chain risks found.")
+		return nil
+	}
+	printFindings(findings)
+	fmt.Println()
+	return strictError(nil, f, ref.name, findings)
+}
+
+// verifyInstall runs the checks on the package a new server will run and
+// prints the findings. Under --strict, findings, or metadata that cannot be
+// fetched, stop the install.
+func verifyInstall(ctx context.Context, ref packageRef, f verifyFlags) error {
+	version, findings, err := checkPackage(ctx, newRegistries(npmFlags{}), ref, f)
+	if err != nil {
+		if f.strict {
+			return fmt.Errorf("cannot check %s for supply-chain risks: %w", ref.name, err)
+		}
+		warnf("could not check %s for supply-chain risks: %v", ref.name, err)
+		return nil
+	}
+	if len(findings) == 0 {
+		fmt.Printf("Supply-chain checks passed for %s@%s.\n", ref.name, version)
+		return nil
+	}
+	fmt.Printf("Supply-chain findings for %s@%s:\n", ref.name, version)
+	printFindings(findings)
+	return strictError(nil, f, ref.name, findings)
+}
+
+// checkUpdateRisks inspects the version an update moves to. Under --strict
+// a server with findings is not updated.
+func checkUpdateRisks(ctx context.Context, update *ServerUpdate, sources registries, f verifyFlags) {
+	ref := packageRef{registry: update.Registry, name: update.PackageName, spec: update.TargetVersion}
+	_, findings, err := checkPackage(ctx, sources, ref, f)
+	if err != nil {
+		findings = []provenance.Finding{{Kind: provenance.KindUnverified, Message: err.Error()}}
+	}
+	update.Risks = findings
+	if f.strict && len(findings) > 0 {
+		update.CanUpdate, update.Blocked = false, true
+		update.Reason = fmt.Sprintf("blocked by --strict: %d supply-chain finding(s)", len(findings))
+	}
+}
+
+// strictError fails a --strict run that has findings.
+func strictError(err error, f verifyFlags, name string, findings []provenance.Finding) error {
+	if err == nil && f.strict && len(findings) > 0 {
+		err = fmt.Errorf("%s has %d supply-chain finding(s) (--strict)", name, len(findings))
+	}
+	return err
+}
+
+func printFindings(findings []provenance.Finding) {
+	for _, finding := range findings {
+		fmt.Printf("   ⚠️  %s: %s\n", finding.Kind, finding.Message)
+	}
+}
+
+// packageRef is the registry package an npx or uvx command runs.
+type packageRef struct {
+	registry string // npm or pypi
+	name     string
+	spec     string // version, tag or range as written; empty for latest
+	pinned   bool   // spec names exactly one version
+}
+
+// registryPackage finds the package of an npx or uvx command. URLs and local
+// paths given to uvx do not come from a registry and are not reported.
+func registryPackage(command string, args []string) (packageRef, bool) {
+	command, args = unwrapExec(command, args)
+	switch command {
+	case "npx":
+		name, spec := extractPackageInfo(args)
+		return packageRef{registry: "npm", name: name, spec: spec, pinned: versions.IsExact(spec)}, name != ""
+	case "uvx":
+		pkg, ok := parseUVXArgs(args)
+		if !ok || pkg.direct || pkg.name == "" {
+			return packageRef{}, false
+		}
+		ref := packageRef{registry: "pypi", name: pkg.name, spec: pkg.version, pinned: pkg.version != ""}
+		if pkg.constraint != "" {
+			ref.spec = pkg.constraint
+		}
+		return ref, true
+	}
+	return packageRef{}, false
+}
+
+// checkPackage resolves ref against its registry and inspects the version
+// it resolves to.
+func checkPackage(ctx context.Context, r registries, ref packageRef, f verifyFlags) (string, []provenance.Finding, error) {
+	var release provenance.Release
+	if ref.registry == "npm" {
+		pkg, err := r.npm.GetPackage(ctx, ref.name)
+		if err != nil {
+			return "", nil, err
+		}
+		version, err := versions.Resolve(ref.spec, pkg.VersionList(), pkg.DistTags)
+		if err != nil {
+			return "", nil, err
+		}
+		release = npmProvenance(pkg, version)
+	} else {
+		if r.offline {
+			return "", nil, fmt.Errorf("PyPI is not available offline")
+		}
+		project, err := r.pypi.GetProject(ctx, ref.name)
+		if err != nil {
+			return "", nil, err
+		}
+		// Yanked releases are not offered, but an exact pin still gets them.
+		version := ref.spec
+		if _, ok := project.Releases[version]; !ok {
+			version, err = versions.Resolve(ref.spec, project.Versions(), map[string]string{"latest": project.LatestVersion()})
+			if err != nil {
+				return "", nil, err
+			}
+		}
+		release = pypiProvenance(project, version)
+	}
+	return release.Version, provenance.Inspect(release, f.options()), nil
+}
+
+func npmProvenance(pkg *npm.PackageDetail, version string) provenance.Release {
+	v := pkg.Versions[version]
+	release := provenance.Release{
+		Registry:       "npm",
+		Name:           pkg.Name,
+		Version:        version,
+		Publishers:     npmPublishers(v),
+		Repository:     v.Repository.URL,
+		InstallScripts: v.InstallScripts(),
+		Deprecated:     v.Deprecated,
+	}
+	if release.Name == "" {
+		release.Name = v.Name
+	}
+	if release.Repository == "" {
+		release.Repository = pkg.Repository.URL
+	}
+
+	published, ok := pkg.Published(version)
+	if !ok {
+		return release
+	}
+	release.Published = published
+
+	// The previous release is the one published last before this one.
+	var previousAt time.Time
+	for other := range pkg.Versions {
+		at, ok := pkg.Published(other)
+		if !ok || !at.Before(published) || at.Before(previousAt) {
+			continue
+		}
+		previousAt, release.PreviousVersion = at, other
+	}
+	if release.PreviousVersion != "" {
+		release.PreviousPublishers = npmPublishers(pkg.Versions[release.PreviousVersion])
+	}
+	return release
+}
+
+// npmPublishers lists the accounts that could publish v.
+func npmPublishers(v npm.PackageVersion) []string {
+	names := make([]string, 0, len(v.Maintainers)+1)
+	for _, m := range v.Maintainers {
+		names = append(names, m.Name)
+	}
+	if v.Publisher != nil && !slices.Contains(names, v.Publisher.Name) {
+		names = append(names, v.Publisher.Name)
+	}
+	return names
+}
+
+// pypiProvenance maps PyPI metadata onto the checks. PyPI does not say who
+// uploaded a release, so maintainer changes are not checked.
+func pypiProvenance(project *pypi.Project, version string) provenance.Release {
+	release := provenance.Release{
+		Registry:   "pypi",
+		Name:       project.Info.Name,
+		Version:    version,
+		Repository: pypiRepository(project.Info),
+	}
+	release.Published, _ = project.Uploaded(version)
+
+	if file, ok := project.Artifact(version); ok && file.PackageType == "sdist" {
+		release.InstallScripts = []string{"sdist build"}
+	}
+
+	files := project.Releases[version]
+	yanked := len(files) > 0
+	var reason string
+	for _, file := range files {
+		yanked = yanked && file.Yanked
+		reason = cmp.Or(reason, file.YankedReason)
+	}
+	if yanked {
+		release.Deprecated = cmp.Or(reason, "yanked")
+	}
+	return release
+}
+
+// codeHosts are where project URLs count as a source repository.
+var codeHosts = []string{"github.com", "gitlab.com", "codeberg.org", "bitbucket.org", "sr.ht"}
+
+// pypiRepository finds a source repository among a project's URLs.
+func pypiRepository(info pypi.Info) string {
+	candidates := []string{info.HomePage}
+	for _, label := range slices.Sorted(maps.Keys(info.ProjectURLs)) {
+		candidates = append(candidates, info.ProjectURLs[label])
+	}
+	for _, c := range candidates {
+		u, err := url.Parse(c)
+		if err != nil {
+			continue
+		}
+		host := strings.TrimPrefix(u.Hostname(), "www.")
+		if slices.ContainsFunc(codeHosts, func(h string) bool { return host == h || strings.HasSuffix(host, "."+h) }) {
+			return c
+		}
+	}
+	return ""
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package fsutil writes files atomically and guards them with advisory
+// locks, for the Claude config and mcp-plugin's own files alike.
+package fsutil
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// filePerm is the permission of lock files.
+const filePerm = 0o600
+
+const (
+	// lockTimeout bounds how long a write waits for another mcp-plugin process.
+	lockTimeout       = 10 * time.Second
+	lockRetryInterval = 50 * time.Millisecond
+)
+
+// errLockHeld is returned by tryLock when another process holds the lock.
+var errLockHeld = errors.New("lock held by another process")
+
+// LockFile returns the name of the lock file in dir that guards path.
+func LockFile(dir, path string) string {
+	sum := sha256.Sum256([]byte(path))
+	return filepath.Join(dir, hex.EncodeToString(sum[:8])+".lock")
+}
+
+// Lock acquires lockFile, which guards path, waiting up to lockTimeout.
+func Lock(lockFile, path string) (unlock func(), err error) {
+	if err := os.MkdirAll(filepath.Dir(lockFile), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory: %w", err)
+	}
+
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		unlock, err := tryLock(lockFile)
+		if err == nil {
+			return unlock, nil
+		}
+		if !errors.Is(err, errLockHeld) {
+			return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for lock on %s", path)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// WriteFile replaces path with data: it writes a synced temporary file
+// beside path and renames it over path, so readers never see a partial
+// file. Callers that read, change and write a file hold its Lock throughout.
+func WriteFile(path string, data []byte, perm fs.FileMode) error {
+	dir := filepath.Dir(path)
+	tmp, err := CreateTemp(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if err := WriteAndSync(tmp, data, perm); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+	SyncDir(dir)
+	return nil
+}
+
+// Update runs a locked read-modify-write of path, a file in mcp-plugin's own
+// directories: the lock file sits beside it. change gets the current
+// content, nil when path does not exist, and returns the new one.
+func Update(path string, perm fs.FileMode, change func(current []byte) ([]byte, error)) error {
+	unlock, err := Lock(path+".lock", path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// #nosec G304 -- path is one of mcp-plugin's own files
+	current, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	data, err := change(current)
+	if err != nil {
+		return err
+	}
+	return WriteFile(path, data, perm)
+}
+
+// CreateTemp creates the temporary file a write of path goes through.
+func CreateTemp(path string) (*os.File, error) {
+	return os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+}
+
+// WriteAndSync writes data to f, sets its mode, flushes it to disk and
+// closes it.
+func WriteAndSync(f *os.File, data []byte, mode fs.FileMode) error {
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Chmod(mode); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// SyncDir flushes a directory entry so a rename survives a crash.
+// Not every platform supports syncing directories, so failures are ignored.
+func SyncDir(dir string) {
+	// #nosec G304 -- dir is the parent of a managed file
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	_ = d.Sync()
+	_ = d.Close()
+}
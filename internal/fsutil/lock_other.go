@@ -0,0 +1,36 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+//go:build !unix
+
+package fsutil
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// staleLockAge is how old a lock file must be before it is assumed to be
+// left over from a crashed process.
+const staleLockAge = time.Minute
+
+// tryLock creates lockFile exclusively. Platforms without flock fall back
+// to this, so stale lock files are reclaimed after staleLockAge.
+func tryLock(lockFile string) (func(), error) {
+	// #nosec G304 -- lockFile is derived from the path it guards
+	f, err := os.OpenFile(lockFile, os.O_CREATE|os.O_EXCL|os.O_WRONLY, filePerm)
+	if errors.Is(err, fs.ErrExist) {
+		if info, statErr := os.Stat(lockFile); statErr == nil && time.Since(info.ModTime()) > staleLockAge {
+			_ = os.Remove(lockFile)
+		}
+		return nil, errLockHeld
+	}
+	if err != nil {
+		return nil, err
+	}
+	_ = f.Close()
+
+	return func() { _ = os.Remove(lockFile) }, nil
+}
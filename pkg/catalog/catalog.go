@@ -0,0 +1,473 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package catalog is a curated list of known MCP servers and how to
+// configure them. An entry is a template: the package or URL, default
+// arguments, and the environment variables, headers and parameters the
+// server needs. Expand turns a template and the user's values into a server
+// entry.
+//
+// The catalog is bundled with the binary, can be replaced by a downloaded
+// copy, and can be extended or overridden by local overlay files, e.g. for
+// company-internal servers.
+package catalog
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli-mcp-plugin/internal/fsutil"
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/config"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed catalog.yaml
+var bundled []byte
+
+// Bundled is the source of entries from the catalog built into the binary.
+const Bundled = "bundled"
+
+// DefaultURL is where `catalog update` downloads the catalog from: the
+// bundled file on the main branch.
+const DefaultURL = "https://raw.githubusercontent.com/gizzahub/gzh-cli-mcp-plugin/main/pkg/catalog/catalog.yaml"
+
+// OverlayEnv lists extra overlay files, separated like PATH.
+const OverlayEnv = "MCP_PLUGIN_CATALOG"
+
+// RepoFileName is the per-repository overlay, next to the project's .mcp.json.
+const RepoFileName = "mcp-plugin.catalog.yaml"
+
+// Transports.
+const (
+	TransportStdio = "stdio"
+	TransportHTTP  = "http"
+	TransportSSE   = "sse"
+)
+
+// Registries of stdio packages.
+const (
+	RegistryNpm  = "npm"
+	RegistryPyPI = "pypi"
+)
+
+// Template is one catalog entry.
+type Template struct {
+	// Name is the entry's key in the catalog and the default server name.
+	Name string `json:"name"`
+	// Source is the file the entry was read from, or Bundled.
+	Source string `json:"source,omitempty"`
+	// Replaces is the source of the entry of the same name this one
+	// overrides, when an overlay redefines it.
+	Replaces string `json:"replaces,omitempty"`
+
+	Description string `json:"description"`
+	Homepage    string `json:"homepage,omitempty"`
+	// Transport is stdio, http or sse; empty means stdio.
+	Transport string `json:"transport,omitempty"`
+	// Registry is npm or pypi for Package; empty means npm.
+	Registry string `json:"registry,omitempty"`
+	Package  string `json:"package,omitempty"`
+	// Command runs a program instead of a registry package.
+	Command string   `json:"command,omitempty"`
+	Args    []string `json:"args,omitempty"`
+	URL     string   `json:"url,omitempty"`
+
+	Env     []Var `json:"env,omitempty"`
+	Headers []Var `json:"headers,omitempty"`
+	Params  []Var `json:"params,omitempty"`
+}
+
+// Var is a value the user supplies: an environment variable, a header or a
+// parameter substituted into args and url as {{NAME}}.
+type Var struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+	// Secret values should be given as secret references and not echoed.
+	Secret  bool   `json:"secret,omitempty"`
+	Default string `json:"default,omitempty"`
+	// Format is how a prompted or default value is written, with {value}
+	// standing for it, e.g. "Bearer {value}".
+	Format string `json:"format,omitempty"`
+}
+
+// Write formats a prompted or default value for the configuration.
+func (v Var) Write(value string) string {
+	if v.Format == "" {
+		return value
+	}
+	return strings.ReplaceAll(v.Format, "{value}", value)
+}
+
+// Kind says where an input goes.
+type Kind string
+
+// Input kinds.
+const (
+	KindEnv    Kind = "env"
+	KindHeader Kind = "header"
+	KindParam  Kind = "param"
+)
+
+// Input is a value a template takes.
+type Input struct {
+	Kind Kind
+	Var
+}
+
+// Values are what the user supplied, by variable name, as they are to be
+// written: Var.Write formats a bare answer to a prompt. Env and Headers may
+// also hold names the template does not declare.
+type Values struct {
+	Env     map[string]string
+	Headers map[string]string
+	Params  map[string]string
+}
+
+var placeholder = regexp.MustCompile(`\{\{([A-Za-z0-9_]+)\}\}`)
+
+// Missing returns the required inputs v does not supply and that have no
+// default, in template order.
+func (t *Template) Missing(v Values) []Input {
+	var missing []Input
+	for _, in := range t.Inputs() {
+		if in.Required && in.Default == "" && lookup(v, in) == "" {
+			missing = append(missing, in)
+		}
+	}
+	return missing
+}
+
+// Inputs returns the params, env vars and headers of t, in that order.
+func (t *Template) Inputs() []Input {
+	var inputs []Input
+	for _, group := range []struct {
+		kind Kind
+		vars []Var
+	}{{KindParam, t.Params}, {KindEnv, t.Env}, {KindHeader, t.Headers}} {
+		for _, v := range group.vars {
+			inputs = append(inputs, Input{Kind: group.kind, Var: v})
+		}
+	}
+	return inputs
+}
+
+func lookup(v Values, in Input) string {
+	values := v.Params
+	switch in.Kind {
+	case KindEnv:
+		values = v.Env
+	case KindHeader:
+		values = v.Headers
+	}
+	return get(in.Kind, values, in.Name)
+}
+
+// get looks name up in values the way kind compares names: headers are
+// case-insensitive.
+func get(kind Kind, values map[string]string, name string) string {
+	for k, value := range values {
+		if sameName(kind, k, name) {
+			return value
+		}
+	}
+	return ""
+}
+
+// Expand builds the server entry for t from v, filling in defaults.
+func (t *Template) Expand(v Values) (config.MCPServerEntry, error) {
+	if missing := t.Missing(v); len(missing) > 0 {
+		names := make([]string, len(missing))
+		for i, in := range missing {
+			names[i] = fmt.Sprintf("%s %s", in.Kind, in.Name)
+		}
+		return config.MCPServerEntry{}, fmt.Errorf("%s needs %s", t.Name, strings.Join(names, ", "))
+	}
+	for name := range v.Params {
+		if !slices.ContainsFunc(t.Params, func(p Var) bool { return p.Name == name }) {
+			return config.MCPServerEntry{}, fmt.Errorf("%s has no parameter %s", t.Name, name)
+		}
+	}
+
+	params := make(map[string]string, len(t.Params))
+	for _, p := range t.Params {
+		params[p.Name] = cmp.Or(v.Params[p.Name], p.Write(p.Default))
+	}
+	expand := func(s string) string {
+		return placeholder.ReplaceAllStringFunc(s, func(m string) string {
+			return params[m[2:len(m)-2]]
+		})
+	}
+	args := make([]string, 0, len(t.Args))
+	for _, a := range t.Args {
+		args = append(args, expand(a))
+	}
+
+	var entry config.MCPServerEntry
+	switch t.transport() {
+	case TransportHTTP, TransportSSE:
+		entry = config.MCPServerEntry{Type: t.transport(), URL: expand(t.URL)}
+		entry.Headers = fill(t.Headers, v.Headers, KindHeader)
+	default:
+		entry = config.MCPServerEntry{Type: config.TypeStdio}
+		switch {
+		case t.Command != "":
+			entry.Command, entry.Args = t.Command, args
+		case t.Registry == RegistryPyPI:
+			entry.Command, entry.Args = "uvx", append([]string{t.Package}, args...)
+		default:
+			entry.Command, entry.Args = "npx", append([]string{"-y", t.Package}, args...)
+		}
+		entry.Env = fill(t.Env, v.Env, KindEnv)
+	}
+	return entry, nil
+}
+
+// fill writes the given values, under the declared spelling of their names,
+// and the defaults of vars that were not given.
+func fill(vars []Var, given map[string]string, kind Kind) map[string]string {
+	out := make(map[string]string)
+	for name, value := range given {
+		if i := slices.IndexFunc(vars, func(v Var) bool { return sameName(kind, v.Name, name) }); i >= 0 {
+			name = vars[i].Name
+		}
+		out[name] = value
+	}
+	for _, v := range vars {
+		if get(kind, given, v.Name) == "" && v.Default != "" {
+			out[v.Name] = v.Write(v.Default)
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func sameName(kind Kind, a, b string) bool {
+	if kind == KindHeader {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
+
+func (t *Template) transport() string {
+	return cmp.Or(t.Transport, TransportStdio)
+}
+
+// validate reports template mistakes that would produce a broken entry.
+func (t *Template) validate() error {
+	switch t.transport() {
+	case TransportStdio:
+		if (t.Package == "") == (t.Command == "") {
+			return errors.New("stdio servers need exactly one of package and command")
+		}
+		if t.URL != "" || len(t.Headers) > 0 {
+			return errors.New("url and headers apply only to http and sse servers")
+		}
+		if r := t.Registry; r != "" && r != RegistryNpm && r != RegistryPyPI {
+			return fmt.Errorf("unknown registry %q (want npm or pypi)", r)
+		}
+	case TransportHTTP, TransportSSE:
+		if t.URL == "" {
+			return fmt.Errorf("%s servers need a url", t.Transport)
+		}
+		if t.Package != "" || t.Command != "" || len(t.Args) > 0 || len(t.Env) > 0 {
+			return errors.New("package, command, args and env apply only to stdio servers")
+		}
+	default:
+		return fmt.Errorf("unknown transport %q (want stdio, http or sse)", t.Transport)
+	}
+
+	for _, vars := range [][]Var{t.Env, t.Headers, t.Params} {
+		for _, v := range vars {
+			if v.Name == "" {
+				return errors.New("every env var, header and param needs a name")
+			}
+			if v.Format != "" && !strings.Contains(v.Format, "{value}") {
+				return fmt.Errorf("format of %s does not contain {value}", v.Name)
+			}
+		}
+	}
+	for _, s := range append(slices.Clone(t.Args), t.URL) {
+		for _, m := range placeholder.FindAllStringSubmatch(s, -1) {
+			if !slices.ContainsFunc(t.Params, func(p Var) bool { return p.Name == m[1] }) {
+				return fmt.Errorf("{{%s}} is not a declared param", m[1])
+			}
+		}
+	}
+	return nil
+}
+
+// file is the layout of a catalog or overlay file.
+type file struct {
+	Servers map[string]*Template `json:"servers"`
+}
+
+// Parse parses a YAML or JSON catalog. Unknown keys and invalid entries are
+// errors.
+func Parse(data []byte, source string) (map[string]*Template, error) {
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	var f file
+	if doc != nil {
+		raw, err := json.Marshal(doc)
+		if err != nil {
+			return nil, fmt.Errorf("unsupported catalog structure: %w", err)
+		}
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&f); err != nil {
+			return nil, err
+		}
+	}
+
+	for name, t := range f.Servers {
+		if t == nil {
+			return nil, fmt.Errorf("server %s: empty entry", name)
+		}
+		t.Name, t.Source = name, source
+		if err := t.validate(); err != nil {
+			return nil, fmt.Errorf("server %s: %w", name, err)
+		}
+	}
+	return f.Servers, nil
+}
+
+// Catalog is the bundled or downloaded catalog with overlays applied.
+type Catalog struct {
+	servers map[string]*Template
+}
+
+// Load reads the catalog at base, or the bundled one when base is empty or
+// does not exist, and applies the overlays in order: an overlay entry
+// replaces the entry of the same name and records its source in Replaces.
+// Overlays that do not exist are skipped.
+func Load(base string, overlays ...string) (*Catalog, error) {
+	data, source := bundled, Bundled
+	if base != "" {
+		// #nosec G304 -- base is the catalog cache in the state directory
+		switch b, err := os.ReadFile(base); {
+		case err == nil:
+			data, source = b, base
+		case !errors.Is(err, fs.ErrNotExist):
+			return nil, fmt.Errorf("failed to read catalog: %w", err)
+		}
+	}
+	servers, err := Parse(data, source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid catalog %s: %w", source, err)
+	}
+	if servers == nil {
+		servers = make(map[string]*Template)
+	}
+	c := &Catalog{servers: servers}
+
+	for _, p := range overlays {
+		// #nosec G304 -- overlay locations are fixed or chosen by the user
+		data, err := os.ReadFile(p)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read catalog overlay: %w", err)
+		}
+		servers, err := Parse(data, p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid catalog overlay %s: %w", p, err)
+		}
+		for name, t := range servers {
+			if old, ok := c.servers[name]; ok {
+				t.Replaces = old.Source
+			}
+		}
+		maps.Copy(c.servers, servers)
+	}
+	return c, nil
+}
+
+// Get returns the entry called name.
+func (c *Catalog) Get(name string) (*Template, bool) {
+	t, ok := c.servers[name]
+	return t, ok
+}
+
+// List returns every entry, sorted by name.
+func (c *Catalog) List() []*Template {
+	return slices.SortedFunc(maps.Values(c.servers), func(a, b *Template) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+}
+
+// Search returns the entries whose name, description or package contains
+// query, ignoring case, sorted by name.
+func (c *Catalog) Search(query string) []*Template {
+	q := strings.ToLower(query)
+	var found []*Template
+	for _, t := range c.List() {
+		for _, s := range []string{t.Name, t.Description, t.Package} {
+			if strings.Contains(strings.ToLower(s), q) {
+				found = append(found, t)
+				break
+			}
+		}
+	}
+	return found
+}
+
+// Save writes a downloaded catalog to path, replacing it atomically.
+func Save(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return fsutil.WriteFile(path, data, 0o600)
+}
+
+// maxSize bounds a downloaded catalog.
+const maxSize = 4 << 20
+
+// Fetch downloads the catalog at url and checks that it parses, so a bad
+// download never replaces a working catalog.
+func Fetch(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download catalog: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download catalog: %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download catalog: %w", err)
+	}
+	if len(data) > maxSize {
+		return nil, fmt.Errorf("catalog at %s is larger than %d bytes", url, maxSize)
+	}
+	if _, err := Parse(data, url); err != nil {
+		return nil, fmt.Errorf("invalid catalog at %s: %w", url, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,197 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package catalog
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBundled(t *testing.T) {
+	c, err := Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	for _, name := range []string{"github", "filesystem", "context7", "fetch", "notion"} {
+		tmpl, ok := c.Get(name)
+		if !ok {
+			t.Errorf("bundled catalog has no %s", name)
+			continue
+		}
+		if tmpl.Source != Bundled || tmpl.Description == "" {
+			t.Errorf("%s = %+v, want a described bundled entry", name, tmpl)
+		}
+	}
+	// Their npm packages are deprecated upstream.
+	for _, name := range []string{"postgres", "slack"} {
+		if _, ok := c.Get(name); ok {
+			t.Errorf("bundled catalog has %s, whose package is deprecated", name)
+		}
+	}
+	list := c.List()
+	for i := 1; i < len(list); i++ {
+		if list[i-1].Name >= list[i].Name {
+			t.Fatalf("List() not sorted: %s before %s", list[i-1].Name, list[i].Name)
+		}
+	}
+}
+
+func TestExpand(t *testing.T) {
+	c, err := Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	get := func(name string) *Template {
+		tmpl, ok := c.Get(name)
+		if !ok {
+			t.Fatalf("no %s entry", name)
+		}
+		return tmpl
+	}
+
+	// Defaults fill params; npm packages run through npx -y.
+	entry, err := get("filesystem").Expand(Values{})
+	if err != nil {
+		t.Fatalf("Expand(filesystem) error = %v", err)
+	}
+	if want := []string{"-y", "@modelcontextprotocol/server-filesystem", "."}; entry.Command != "npx" || !reflect.DeepEqual(entry.Args, want) {
+		t.Errorf("filesystem = %s %v, want npx %v", entry.Command, entry.Args, want)
+	}
+
+	entry, err = get("git").Expand(Values{Params: map[string]string{"REPOSITORY": "/src/app"}})
+	if err != nil {
+		t.Fatalf("Expand(git) error = %v", err)
+	}
+	if want := []string{"mcp-server-git", "--repository", "/src/app"}; entry.Command != "uvx" || !reflect.DeepEqual(entry.Args, want) {
+		t.Errorf("git = %s %v, want uvx %v", entry.Command, entry.Args, want)
+	}
+
+	// Header names match case-insensitively and keep the declared spelling.
+	entry, err = get("github").Expand(Values{Headers: map[string]string{"authorization": "Bearer ${keyring:github}"}})
+	if err != nil {
+		t.Fatalf("Expand(github) error = %v", err)
+	}
+	if entry.Type != "http" || entry.Headers["Authorization"] != "Bearer ${keyring:github}" || len(entry.Headers) != 1 {
+		t.Errorf("github = %+v, want an http entry with the Authorization header", entry)
+	}
+
+	// Extra env vars are kept.
+	entry, err = get("tavily").Expand(Values{Env: map[string]string{"TAVILY_API_KEY": "k", "DEBUG": "1"}})
+	if err != nil {
+		t.Fatalf("Expand(tavily) error = %v", err)
+	}
+	if want := map[string]string{"TAVILY_API_KEY": "k", "DEBUG": "1"}; !reflect.DeepEqual(entry.Env, want) {
+		t.Errorf("tavily env = %v, want %v", entry.Env, want)
+	}
+
+	if _, err := get("time").Expand(Values{Params: map[string]string{"TZ": "UTC"}}); err == nil {
+		t.Error("Expand() with an undeclared param: error = nil")
+	}
+}
+
+func TestMissing(t *testing.T) {
+	c, err := Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	supabase, _ := c.Get("supabase")
+	missing := supabase.Missing(Values{Env: map[string]string{"SUPABASE_ACCESS_TOKEN": "t"}})
+	if len(missing) != 1 || missing[0].Kind != KindParam || missing[0].Name != "PROJECT_REF" {
+		t.Fatalf("Missing() = %+v, want param PROJECT_REF", missing)
+	}
+	if _, err := supabase.Expand(Values{}); err == nil || !strings.Contains(err.Error(), "env SUPABASE_ACCESS_TOKEN") {
+		t.Errorf("Expand() error = %v, want missing inputs listed", err)
+	}
+	if got := (Var{Format: "Bearer {value}"}).Write("abc"); got != "Bearer abc" {
+		t.Errorf("Write() = %q, want %q", got, "Bearer abc")
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, doc := range []string{
+		"servers: {x: {package: p, descripton: typo}}",
+		"servers: {x: {description: no package}}",
+		"servers: {x: {package: p, command: c}}",
+		"servers: {x: {transport: http}}",
+		"servers: {x: {transport: http, url: 'https://h', env: [{name: A}]}}",
+		"servers: {x: {transport: grpc, url: 'https://h'}}",
+		"servers: {x: {package: p, registry: cargo}}",
+		"servers: {x: {package: p, args: ['{{ROOT}}']}}",
+		"servers: {x: {package: p, env: [{name: A, format: 'Bearer'}]}}",
+		"servers: {x: }",
+	} {
+		if _, err := Parse([]byte(doc), "test"); err == nil {
+			t.Errorf("Parse(%q) error = nil, want error", doc)
+		}
+	}
+}
+
+func TestLoad_Overlays(t *testing.T) {
+	dir := t.TempDir()
+	cache := filepath.Join(dir, "cache.yaml")
+	user := filepath.Join(dir, "user.yaml")
+	repo := filepath.Join(dir, RepoFileName)
+	write := func(path, data string) {
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(cache, "servers: {memory: {description: cached, package: mem}, tools: {description: cached tools, package: t}}")
+	write(user, "servers: {internal: {description: internal, command: /opt/mcp/internal}}")
+	write(repo, "servers: {tools: {description: repo tools, transport: http, url: 'https://tools.corp.example/mcp'}}")
+
+	c, err := Load(cache, user, filepath.Join(dir, "missing.yaml"), repo)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if _, ok := c.Get("github"); ok {
+		t.Error("a downloaded catalog should replace the bundled one")
+	}
+	if tmpl, ok := c.Get("internal"); !ok || tmpl.Source != user {
+		t.Errorf("internal = %+v, want it from the user overlay", tmpl)
+	}
+	if tmpl, ok := c.Get("tools"); !ok || tmpl.Source != repo || tmpl.URL == "" || tmpl.Replaces != cache {
+		t.Errorf("tools = %+v, want the repository overlay to win over the cache", tmpl)
+	}
+	if tmpl, _ := c.Get("internal"); tmpl.Replaces != "" {
+		t.Errorf("internal.Replaces = %q, want empty for a new entry", tmpl.Replaces)
+	}
+	if got := c.Search("TOOLS"); len(got) != 1 || got[0].Name != "tools" {
+		t.Errorf("Search() = %v, want tools", got)
+	}
+
+	write(user, "servers: [not, a, map]")
+	if _, err := Load(cache, user); err == nil {
+		t.Error("Load() with a bad overlay: error = nil")
+	}
+}
+
+func TestFetch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/catalog.yaml":
+			_, _ = w.Write([]byte("servers: {memory: {description: m, package: mem}}"))
+		case "/broken.yaml":
+			_, _ = w.Write([]byte("servers: {memory: {}}"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	if _, err := Fetch(context.Background(), srv.URL+"/catalog.yaml"); err != nil {
+		t.Errorf("Fetch() error = %v", err)
+	}
+	for _, path := range []string{"/broken.yaml", "/missing.yaml"} {
+		if _, err := Fetch(context.Background(), srv.URL+path); err == nil {
+			t.Errorf("Fetch(%s) error = nil, want error", path)
+		}
+	}
+}
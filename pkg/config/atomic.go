@@ -0,0 +1,206 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gizzahub/gzh-cli-mcp-plugin/internal/fsutil"
+)
+
+// ErrConcurrentModification is returned when a config file keeps changing
+// underneath a write (typically because Claude Code rewrote it).
+var ErrConcurrentModification = errors.New("file was modified concurrently; retry the command")
+
+// maxWriteAttempts bounds how often a read-modify-write is retried after a
+// concurrent modification was detected.
+const maxWriteAttempts = 3
+
+// fileState fingerprints a file so changes between read and write can be detected.
+type fileState struct {
+	exists  bool
+	modTime time.Time
+	size    int64
+	sum     [sha256.Size]byte
+}
+
+func (s fileState) equal(o fileState) bool {
+	return s.exists == o.exists && s.modTime.Equal(o.modTime) && s.size == o.size && s.sum == o.sum
+}
+
+// readState reads path and returns its content with a fingerprint.
+// A missing file yields nil data and a state with exists=false.
+func readState(path string) ([]byte, fileState, error) {
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fileState{}, nil
+	}
+	if err != nil {
+		return nil, fileState{}, err
+	}
+
+	// #nosec G304 -- path is one of the Claude config files managed by Writer
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fileState{}, err
+	}
+
+	return data, fileState{
+		exists:  true,
+		modTime: info.ModTime(),
+		size:    info.Size(),
+		sum:     sha256.Sum256(data),
+	}, nil
+}
+
+// restoreConfig atomically replaces path with the content of backup restored.
+func (w *Writer) restoreConfig(path string, data []byte, restored string) error {
+	return w.modifyFileRestoring(path, restored, func([]byte) ([]byte, error) { return data, nil })
+}
+
+// modifyJSON runs a read-modify-write transaction on a JSON object file.
+// label names the file in error messages. A missing file is an error.
+func (w *Writer) modifyJSON(path, label string, mutate func(map[string]any) error) error {
+	return w.modifyJSONFile(path, label, false, mutate)
+}
+
+// modifyJSONFile is modifyJSON with control over missing files: when create
+// is set, a missing file is treated as an empty object and created.
+func (w *Writer) modifyJSONFile(path, label string, create bool, mutate func(map[string]any) error) error {
+	return w.modifyFile(path, func(current []byte) ([]byte, error) {
+		var layout jsonLayout
+		if current == nil {
+			if !create {
+				return nil, fmt.Errorf("failed to read %s: %w", label, fs.ErrNotExist)
+			}
+			current = []byte("{}")
+			layout = parseLayout(current)
+			layout.newline = true
+		} else {
+			layout = parseLayout(current)
+		}
+
+		// Parse as generic map to preserve all fields
+		cfg, err := decodeJSONObject(current)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", label, err)
+		}
+		if cfg == nil {
+			cfg = make(map[string]any)
+		}
+
+		if err := mutate(cfg); err != nil {
+			return nil, err
+		}
+
+		// Write back with the original key order and indentation
+		output, err := layout.marshal(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s: %w", label, err)
+		}
+		return output, nil
+	})
+}
+
+// modifyFile is the single write path for Claude config files. Under an
+// advisory lock it reads the file, computes the new content, snapshots the
+// old content and atomically replaces the file. If the file changes between
+// read and replace, the whole cycle is retried so concurrent edits by Claude
+// Code are never silently clobbered.
+func (w *Writer) modifyFile(path string, transform func(current []byte) ([]byte, error)) error {
+	return w.modifyFileRestoring(path, "", transform)
+}
+
+// modifyFileRestoring is modifyFile for a rollback: the snapshot of the old
+// content records restored, the ID of the backup being restored.
+func (w *Writer) modifyFileRestoring(path, restored string, transform func(current []byte) ([]byte, error)) error {
+	unlock, err := w.lock(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	for range maxWriteAttempts {
+		current, state, err := readState(path)
+		if err != nil {
+			return err
+		}
+
+		output, err := transform(current)
+		if err != nil {
+			return err
+		}
+
+		err = w.replaceFile(path, output, current, state, restored)
+		if errors.Is(err, ErrConcurrentModification) {
+			continue
+		}
+		return err
+	}
+
+	return ErrConcurrentModification
+}
+
+// replaceFile writes data to a temp file next to path, verifies path still
+// matches the state it was read in, snapshots the previous content and
+// renames the temp file over path. restored is recorded on the snapshot as in
+// modifyFileRestoring.
+func (w *Writer) replaceFile(path string, data, previous []byte, state fileState, restored string) error {
+	if state.exists && bytes.Equal(data, previous) {
+		return nil
+	}
+
+	tmp, err := fsutil.CreateTemp(path)
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	committed := false
+	defer func() {
+		if !committed {
+			_ = os.Remove(tmpPath)
+		}
+	}()
+
+	if err := fsutil.WriteAndSync(tmp, data, fileMode(path)); err != nil {
+		return err
+	}
+
+	_, now, err := readState(path)
+	if err != nil {
+		return err
+	}
+	if !now.equal(state) {
+		return ErrConcurrentModification
+	}
+
+	if state.exists {
+		if err := w.snapshot(path, previous, restored); err != nil {
+			return fmt.Errorf("backup failed: %w", err)
+		}
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+	committed = true
+
+	fsutil.SyncDir(filepath.Dir(path))
+	return nil
+}
+
+// fileMode keeps the permissions of an existing file, defaulting to filePerm.
+func fileMode(path string) fs.FileMode {
+	if info, err := os.Stat(path); err == nil {
+		return info.Mode().Perm()
+	}
+	return filePerm
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriter_ModifyFileRetriesOnConcurrentChange(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "config.json")
+	if err := os.WriteFile(path, []byte("original"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	writer := &Writer{homeDir: tmpDir}
+	calls := 0
+	err := writer.modifyFile(path, func(current []byte) ([]byte, error) {
+		calls++
+		if calls == 1 {
+			// Simulate Claude Code rewriting the file mid-transaction.
+			if err := os.WriteFile(path, []byte("external"), 0600); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return append(current, "+ours"...), nil
+	})
+	if err != nil {
+		t.Fatalf("modifyFile() error = %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("transform called %d times, want 2", calls)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "external+ours" {
+		t.Errorf("content = %q, want the external change preserved", data)
+	}
+}
+
+func TestWriter_ModifyFileGivesUp(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "config.json")
+	if err := os.WriteFile(path, []byte("original"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	writer := &Writer{homeDir: tmpDir}
+	calls := 0
+	err := writer.modifyFile(path, func(current []byte) ([]byte, error) {
+		calls++
+		if err := os.WriteFile(path, []byte{byte('a' + calls)}, 0600); err != nil {
+			t.Fatal(err)
+		}
+		return []byte("ours"), nil
+	})
+	if !errors.Is(err, ErrConcurrentModification) {
+		t.Fatalf("modifyFile() error = %v, want ErrConcurrentModification", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if string(data) == "ours" {
+		t.Error("concurrent change should not be clobbered")
+	}
+}
+
+func TestWriter_AtomicWriteLeavesNoTempFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := writeTestClaudeJSON(t, tmpDir, map[string]any{})
+	if err := os.Chmod(path, 0640); err != nil {
+		t.Fatal(err)
+	}
+
+	writer := &Writer{homeDir: tmpDir}
+	if err := writer.AddMCPServer("test", MCPServerEntry{Command: "npx"}); err != nil {
+		t.Fatalf("AddMCPServer() error = %v", err)
+	}
+
+	entries, _ := os.ReadDir(tmpDir)
+	for _, e := range entries {
+		if e.Name() != ".claude.json" && e.Name() != ".claude" {
+			t.Errorf("unexpected leftover file %s", e.Name())
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0640 {
+		t.Errorf("mode = %o, want existing mode 640 kept", info.Mode().Perm())
+	}
+}
+
+func TestWriter_MissingFile(t *testing.T) {
+	writer := &Writer{homeDir: t.TempDir()}
+	if err := writer.AddMCPServer("test", MCPServerEntry{Command: "npx"}); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("AddMCPServer() error = %v, want not-exist error", err)
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultMaxBackups is the number of snapshots kept when Writer.MaxBackups is unset.
+const DefaultMaxBackups = 20
+
+const (
+	// dirPerm is the permission for directories managed by this tool.
+	dirPerm = 0o700
+
+	backupMetaFile    = "meta.json"
+	backupContentFile = "content"
+	backupTimeFormat  = "20060102-150405.000000"
+)
+
+// ErrNoBackups is returned when a rollback is requested but no snapshot exists.
+var ErrNoBackups = errors.New("no backups found")
+
+// Backup describes a snapshot of a config file taken before it was overwritten.
+type Backup struct {
+	ID        string    `json:"id"`
+	Path      string    `json:"path"` // Original file location
+	CreatedAt time.Time `json:"created_at"`
+	Size      int64     `json:"size"`
+	// Restored is set on snapshots taken by a rollback to the ID of the
+	// backup it restored.
+	Restored string `json:"restored,omitempty"`
+}
+
+// StateDir returns the directory for files owned by mcp-plugin itself.
+func (w *Writer) StateDir() string {
+	return filepath.Join(w.ClaudeDir(), "mcp-plugin")
+}
+
+// BackupDir returns the directory holding config snapshots.
+func (w *Writer) BackupDir() string {
+	return filepath.Join(w.StateDir(), "backups")
+}
+
+func (w *Writer) maxBackups() int {
+	if w.MaxBackups > 0 {
+		return w.MaxBackups
+	}
+	return DefaultMaxBackups
+}
+
+// snapshot stores data, the current content of path, in the backup directory.
+// restored is the backup a rollback is restoring, if any.
+func (w *Writer) snapshot(path string, data []byte, restored string) error {
+	now := time.Now()
+	backup := Backup{
+		ID:        now.UTC().Format(backupTimeFormat) + "-" + strings.TrimPrefix(filepath.Base(path), "."),
+		Path:      path,
+		CreatedAt: now,
+		Size:      int64(len(data)),
+		Restored:  restored,
+	}
+
+	dir := filepath.Join(w.BackupDir(), backup.ID)
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, backupContentFile), data, filePerm); err != nil {
+		return err
+	}
+	meta, err := json.MarshalIndent(backup, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, backupMetaFile), meta, filePerm); err != nil {
+		return err
+	}
+
+	return w.pruneBackups()
+}
+
+// pruneBackups removes the oldest snapshots beyond the retention limit.
+func (w *Writer) pruneBackups() error {
+	backups, err := w.ListBackups()
+	if err != nil {
+		return err
+	}
+	for _, b := range backups[min(len(backups), w.maxBackups()):] {
+		if err := os.RemoveAll(filepath.Join(w.BackupDir(), b.ID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListBackups returns all snapshots, newest first.
+func (w *Writer) ListBackups() ([]Backup, error) {
+	entries, err := os.ReadDir(w.BackupDir())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backups: %w", err)
+	}
+
+	var backups []Backup
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		metaPath := filepath.Join(w.BackupDir(), entry.Name(), backupMetaFile)
+		// #nosec G304 -- path is under the managed backup directory
+		data, err := os.ReadFile(metaPath)
+		if err != nil {
+			continue
+		}
+		var b Backup
+		if err := json.Unmarshal(data, &b); err != nil || b.ID != entry.Name() {
+			continue
+		}
+		backups = append(backups, b)
+	}
+
+	// IDs start with a sortable UTC timestamp.
+	sort.Slice(backups, func(i, j int) bool { return backups[i].ID > backups[j].ID })
+	return backups, nil
+}
+
+// FindBackup resolves a backup by ID or unique ID prefix.
+// An empty id selects the most recent backup that is neither a rollback's
+// snapshot nor already restored by a later rollback, so repeated rollbacks
+// step back one change each.
+func (w *Writer) FindBackup(id string) (Backup, error) {
+	backups, err := w.ListBackups()
+	if err != nil {
+		return Backup{}, err
+	}
+	if len(backups) == 0 {
+		return Backup{}, ErrNoBackups
+	}
+	if id == "" {
+		return latestBackup(backups)
+	}
+
+	var matches []Backup
+	for _, b := range backups {
+		if b.ID == id {
+			return b, nil
+		}
+		if strings.HasPrefix(b.ID, id) {
+			matches = append(matches, b)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return Backup{}, fmt.Errorf("backup '%s' not found", id)
+	case 1:
+		return matches[0], nil
+	default:
+		return Backup{}, fmt.Errorf("backup ID '%s' is ambiguous (%d matches)", id, len(matches))
+	}
+}
+
+// latestBackup picks the backup an id-less rollback restores from backups,
+// newest first.
+func latestBackup(backups []Backup) (Backup, error) {
+	restored := make(map[string]bool)
+	for _, b := range backups {
+		if b.Restored != "" {
+			// A rollback that was itself undone no longer counts.
+			if !restored[b.ID] {
+				restored[b.Restored] = true
+			}
+			continue
+		}
+		if !restored[b.ID] {
+			return b, nil
+		}
+	}
+	return Backup{}, ErrNoBackups
+}
+
+// Rollback restores the file captured by the given backup (latest when id is
+// empty, see FindBackup). The current content is itself snapshotted first, so
+// a rollback can be undone by restoring that snapshot's ID.
+func (w *Writer) Rollback(id string) (Backup, error) {
+	backup, data, err := w.ReadBackup(id)
+	if err != nil {
+		return Backup{}, err
+	}
+
+	if err := w.restoreConfig(backup.Path, data, backup.ID); err != nil {
+		return Backup{}, fmt.Errorf("failed to restore %s: %w", backup.Path, err)
+	}
+	return backup, nil
+}
+
+// ReadBackup returns a backup (selected as in FindBackup) and its content.
+func (w *Writer) ReadBackup(id string) (Backup, []byte, error) {
+	backup, err := w.FindBackup(id)
+	if err != nil {
+		return Backup{}, nil, err
+	}
+
+	contentPath := filepath.Join(w.BackupDir(), backup.ID, backupContentFile)
+	// #nosec G304 -- path is under the managed backup directory
+	data, err := os.ReadFile(contentPath)
+	if err != nil {
+		return Backup{}, nil, fmt.Errorf("failed to read backup: %w", err)
+	}
+	return backup, data, nil
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func writeTestClaudeJSON(t *testing.T, home string, config map[string]any) string {
+	t.Helper()
+	data, _ := json.MarshalIndent(config, "", "  ")
+	path := filepath.Join(home, ".claude.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestWriter_BackupOnWrite(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := writeTestClaudeJSON(t, tmpDir, map[string]any{"mcpServers": map[string]any{}})
+	original, _ := os.ReadFile(path)
+
+	writer := &Writer{homeDir: tmpDir}
+	if err := writer.AddMCPServer("test", MCPServerEntry{Command: "npx"}); err != nil {
+		t.Fatalf("AddMCPServer() error = %v", err)
+	}
+
+	backups, err := writer.ListBackups()
+	if err != nil {
+		t.Fatalf("ListBackups() error = %v", err)
+	}
+	if len(backups) != 1 {
+		t.Fatalf("Expected 1 backup, got %d", len(backups))
+	}
+	if backups[0].Path != path {
+		t.Errorf("backup path = %s, want %s", backups[0].Path, path)
+	}
+
+	content, err := os.ReadFile(filepath.Join(writer.BackupDir(), backups[0].ID, backupContentFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != string(original) {
+		t.Error("backup content should match the file before the write")
+	}
+}
+
+func TestWriter_BackupRetention(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestClaudeJSON(t, tmpDir, map[string]any{})
+
+	writer := &Writer{homeDir: tmpDir, MaxBackups: 2}
+	for _, name := range []string{"a", "b", "c", "d"} {
+		if err := writer.AddMCPServer(name, MCPServerEntry{Command: "npx"}); err != nil {
+			t.Fatalf("AddMCPServer(%s) error = %v", name, err)
+		}
+	}
+
+	backups, err := writer.ListBackups()
+	if err != nil {
+		t.Fatalf("ListBackups() error = %v", err)
+	}
+	if len(backups) != 2 {
+		t.Errorf("Expected 2 retained backups, got %d", len(backups))
+	}
+}
+
+func TestWriter_Rollback(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestClaudeJSON(t, tmpDir, map[string]any{})
+
+	writer := &Writer{homeDir: tmpDir}
+	if _, err := writer.Rollback(""); !errors.Is(err, ErrNoBackups) {
+		t.Fatalf("Rollback() without backups error = %v, want ErrNoBackups", err)
+	}
+
+	if err := writer.AddMCPServer("test", MCPServerEntry{Command: "npx"}); err != nil {
+		t.Fatalf("AddMCPServer() error = %v", err)
+	}
+
+	if _, err := writer.Rollback(""); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+
+	exists, err := writer.MCPServerExists("test")
+	if err != nil {
+		t.Fatalf("MCPServerExists() error = %v", err)
+	}
+	if exists {
+		t.Error("server should be gone after rollback")
+	}
+
+	// The rollback snapshotted the pre-rollback state, so it can be undone.
+	backups, _ := writer.ListBackups()
+	if len(backups) != 2 {
+		t.Fatalf("Expected 2 backups after rollback, got %d", len(backups))
+	}
+	if _, err := writer.Rollback(backups[0].ID); err != nil {
+		t.Fatalf("Rollback(%s) error = %v", backups[0].ID, err)
+	}
+	exists, _ = writer.MCPServerExists("test")
+	if !exists {
+		t.Error("server should be back after undoing the rollback")
+	}
+}
+
+func TestWriter_RollbackSteps(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestClaudeJSON(t, tmpDir, map[string]any{})
+
+	writer := &Writer{homeDir: tmpDir}
+	for _, name := range []string{"one", "two", "three"} {
+		if err := writer.AddMCPServer(name, MCPServerEntry{Command: "npx"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	servers := func() []string {
+		t.Helper()
+		entries, err := writer.ListMCPServerEntries()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return slices.Sorted(maps.Keys(entries))
+	}
+
+	// Each id-less rollback undoes one more change.
+	for _, want := range [][]string{{"one", "two"}, {"one"}} {
+		if _, err := writer.Rollback(""); err != nil {
+			t.Fatalf("Rollback() error = %v", err)
+		}
+		if got := servers(); !slices.Equal(got, want) {
+			t.Fatalf("servers after rollback = %v, want %v", got, want)
+		}
+	}
+
+	// Undoing the last rollback makes its backup the latest again.
+	backups, _ := writer.ListBackups()
+	if _, err := writer.Rollback(backups[0].ID); err != nil {
+		t.Fatalf("Rollback(%s) error = %v", backups[0].ID, err)
+	}
+	if _, err := writer.Rollback(""); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if got := servers(); !slices.Equal(got, []string{"one"}) {
+		t.Errorf("servers after undo and rollback = %v, want [one]", got)
+	}
+}
+
+func TestWriter_FindBackupPrefix(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestClaudeJSON(t, tmpDir, map[string]any{})
+
+	writer := &Writer{homeDir: tmpDir}
+	if err := writer.AddMCPServer("test", MCPServerEntry{Command: "npx"}); err != nil {
+		t.Fatal(err)
+	}
+	backups, _ := writer.ListBackups()
+
+	found, err := writer.FindBackup(backups[0].ID[:8])
+	if err != nil {
+		t.Fatalf("FindBackup() error = %v", err)
+	}
+	if found.ID != backups[0].ID {
+		t.Errorf("FindBackup() = %s, want %s", found.ID, backups[0].ID)
+	}
+
+	if _, err := writer.FindBackup("nope"); err == nil {
+		t.Error("FindBackup() should fail for unknown ID")
+	}
+}
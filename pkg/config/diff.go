@@ -0,0 +1,185 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FieldChange is a difference in one field of a server entry. Header and env
+// values are compared per key ("headers.Authorization", "env.API_KEY"). An
+// empty Old or New means the field is absent on that side.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old,omitempty"`
+	New   string `json:"new,omitempty"`
+}
+
+// DiffEntries returns the field-level differences from a to b, sorted by field.
+func DiffEntries(a, b MCPServerEntry) []FieldChange {
+	return diffFields(flattenEntry(a), flattenEntry(b), false)
+}
+
+// diffFields compares flattened entries. With onlyNew set, fields missing
+// from b are ignored, matching UpdateMCPServer which keeps unmodeled keys.
+func diffFields(a, b map[string]string, onlyNew bool) []FieldChange {
+	keys := make(map[string]bool, len(a)+len(b))
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+
+	var changes []FieldChange
+	for k := range keys {
+		oldValue, newValue := a[k], b[k]
+		if oldValue == newValue {
+			continue
+		}
+		if _, inB := b[k]; onlyNew && !inB && isUnmodeled(k) {
+			continue
+		}
+		changes = append(changes, FieldChange{Field: k, Old: oldValue, New: newValue})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		ri, rj := rankOf(fieldRoot(changes[i].Field)), rankOf(fieldRoot(changes[j].Field))
+		if ri != rj {
+			return ri < rj
+		}
+		return changes[i].Field < changes[j].Field
+	})
+	return changes
+}
+
+// flattenEntry renders an entry as field -> display value.
+func flattenEntry(e MCPServerEntry) map[string]string {
+	fields := make(map[string]string)
+	for k, raw := range e.Extra {
+		fields[k] = string(raw)
+	}
+	if e.Type != "" {
+		fields["type"] = e.Type
+	}
+	if e.Command != "" {
+		fields["command"] = e.Command
+	}
+	if len(e.Args) > 0 {
+		if args, err := encodeNoEscape(e.Args); err == nil {
+			fields["args"] = string(args)
+		}
+	}
+	if e.URL != "" {
+		fields["url"] = e.URL
+	}
+	for k, v := range e.Headers {
+		fields["headers."+k] = v
+	}
+	for k, v := range e.Env {
+		fields["env."+k] = v
+	}
+	if e.Enabled {
+		fields["enabled"] = strconv.FormatBool(e.Enabled)
+	}
+	return fields
+}
+
+// fieldRoot returns the top-level key of a flattened field name.
+func fieldRoot(field string) string {
+	root, _, _ := strings.Cut(field, ".")
+	return root
+}
+
+func isUnmodeled(field string) bool {
+	_, modeled := newKeyRank[fieldRoot(field)]
+	return !modeled
+}
+
+// ServerDiff describes how one server differs between two configurations.
+type ServerDiff struct {
+	Name   string        `json:"name"`
+	Action Action        `json:"action"` // create (only in b), delete (only in a) or update
+	Fields []FieldChange `json:"fields"`
+}
+
+// DiffServers compares two sets of servers and returns the servers that
+// differ, sorted by name.
+func DiffServers(a, b map[string]MCPServerEntry) []ServerDiff {
+	var diffs []ServerDiff
+	for _, name := range slices.Sorted(maps.Keys(a)) {
+		other, inB := b[name]
+		switch {
+		case !inB:
+			diffs = append(diffs, ServerDiff{Name: name, Action: ActionDelete, Fields: DiffEntries(a[name], MCPServerEntry{})})
+		default:
+			if fields := DiffEntries(a[name], other); len(fields) > 0 {
+				diffs = append(diffs, ServerDiff{Name: name, Action: ActionUpdate, Fields: fields})
+			}
+		}
+	}
+	for name, entry := range b {
+		if _, inA := a[name]; !inA {
+			diffs = append(diffs, ServerDiff{Name: name, Action: ActionCreate, Fields: DiffEntries(MCPServerEntry{}, entry)})
+		}
+	}
+	sort.SliceStable(diffs, func(i, j int) bool { return diffs[i].Name < diffs[j].Name })
+	return diffs
+}
+
+// BackupServers returns a backup selected as in FindBackup and the servers
+// it holds for the Writer's scope. A backup of .mcp.json always yields its
+// mcpServers; a backup of .claude.json yields the user or local scope. An
+// empty id selects the latest backup of the scope's own file.
+func (w *Writer) BackupServers(id string) (Backup, map[string]MCPServerEntry, error) {
+	if id == "" {
+		latest, err := w.latestBackupOf(w.location().path)
+		if err != nil {
+			return Backup{}, nil, err
+		}
+		id = latest.ID
+	}
+
+	backup, data, err := w.ReadBackup(id)
+	if err != nil {
+		return Backup{}, nil, err
+	}
+
+	var keys []string
+	switch base := filepath.Base(backup.Path); {
+	case base == ".mcp.json":
+		keys = []string{"mcpServers"}
+	case base != ".claude.json":
+		return Backup{}, nil, fmt.Errorf("backup %s is of %s, which holds no MCP servers", backup.ID, backup.Path)
+	case w.Scope() == ScopeProject:
+		return Backup{}, nil, fmt.Errorf("backup %s is of %s, not a project .mcp.json", backup.ID, backup.Path)
+	default:
+		keys = w.location().keys
+	}
+
+	servers, err := serversAt(data, keys)
+	if err != nil {
+		return Backup{}, nil, fmt.Errorf("failed to parse backup %s: %w", backup.ID, err)
+	}
+	return backup, servers, nil
+}
+
+// latestBackupOf returns the newest backup of the file at path.
+func (w *Writer) latestBackupOf(path string) (Backup, error) {
+	backups, err := w.ListBackups()
+	if err != nil {
+		return Backup{}, err
+	}
+	for _, b := range backups {
+		if b.Path == path {
+			return b, nil
+		}
+	}
+	return Backup{}, fmt.Errorf("%w for %s", ErrNoBackups, path)
+}
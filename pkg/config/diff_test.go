@@ -0,0 +1,90 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDiffEntries(t *testing.T) {
+	a := MCPServerEntry{
+		Type:    TypeStdio,
+		Command: "npx",
+		Args:    []string{"pkg@1"},
+		Env:     map[string]string{"TOKEN": "old", "KEEP": "x"},
+		Extra:   map[string]json.RawMessage{"timeout": json.RawMessage("5")},
+	}
+	b := MCPServerEntry{
+		Type:    TypeStdio,
+		Command: "npx",
+		Args:    []string{"pkg@2"},
+		Env:     map[string]string{"TOKEN": "new", "KEEP": "x"},
+		Headers: map[string]string{"X-Team": "core"},
+	}
+
+	want := []FieldChange{
+		{Field: "args", Old: `["pkg@1"]`, New: `["pkg@2"]`},
+		{Field: "headers.X-Team", New: "core"},
+		{Field: "env.TOKEN", Old: "old", New: "new"},
+		{Field: "timeout", Old: "5"},
+	}
+	if got := DiffEntries(a, b); !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffEntries() =\n%+v\nwant\n%+v", got, want)
+	}
+	if got := DiffEntries(a, a); len(got) != 0 {
+		t.Errorf("DiffEntries(a, a) = %+v, want none", got)
+	}
+}
+
+func TestDiffServers(t *testing.T) {
+	a := map[string]MCPServerEntry{
+		"same":    {Command: "npx"},
+		"changed": {Command: "npx"},
+		"gone":    {URL: "https://example.com"},
+	}
+	b := map[string]MCPServerEntry{
+		"same":    {Command: "npx"},
+		"changed": {Command: "uvx"},
+		"new":     {Command: "docker"},
+	}
+
+	var got []string
+	for _, d := range DiffServers(a, b) {
+		got = append(got, string(d.Action)+" "+d.Name)
+	}
+	want := []string{"update changed", "delete gone", "create new"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffServers() = %v, want %v", got, want)
+	}
+}
+
+func TestWriter_BackupServers(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestClaudeJSON(t, tmpDir, map[string]any{
+		"mcpServers": map[string]any{"ctx": map[string]any{"command": "npx"}},
+	})
+
+	writer := &Writer{homeDir: tmpDir}
+	if err := writer.RemoveMCPServer("ctx"); err != nil {
+		t.Fatal(err)
+	}
+
+	backup, servers, err := writer.BackupServers("")
+	if err != nil {
+		t.Fatalf("BackupServers() error = %v", err)
+	}
+	if _, ok := servers["ctx"]; !ok || len(servers) != 1 {
+		t.Errorf("BackupServers() = %v, want the removed server", servers)
+	}
+
+	if _, _, err := writer.WithScope(ScopeProject).BackupServers(backup.ID); err == nil {
+		t.Error("expected error reading a claude.json backup as project scope")
+	}
+	if _, _, err := writer.WithScope(ScopeProject).BackupServers(""); !errors.Is(err, ErrNoBackups) {
+		t.Errorf("BackupServers(\"\") error = %v, want ErrNoBackups", err)
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"encoding/json"
+	"maps"
+)
+
+// MCPServerEntry represents an MCP server configuration for installation.
+//
+// Keys that are not modeled here (for example "timeout", or fields added by
+// future Claude Code versions) are carried in Extra, so reading an entry and
+// writing it back never loses data. Extra is flattened into the server object
+// when marshaled. Modeled keys that were present when the entry was read are
+// written back even when false or empty ("enabled": false, "args": []).
+type MCPServerEntry struct {
+	Type    string                     `json:"type,omitempty"`
+	Command string                     `json:"command,omitempty"`
+	Args    []string                   `json:"args,omitempty"`
+	URL     string                     `json:"url,omitempty"`
+	Headers map[string]string          `json:"headers,omitempty"`
+	Env     map[string]string          `json:"env,omitempty"`
+	Enabled bool                       `json:"enabled,omitempty"`
+	Extra   map[string]json.RawMessage `json:"-"`
+
+	present map[string]bool // modeled keys found when decoding
+}
+
+// MarshalJSON writes the modeled fields together with Extra.
+func (e MCPServerEntry) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.toMap())
+}
+
+// UnmarshalJSON reads the modeled fields and keeps everything else in Extra.
+func (e *MCPServerEntry) UnmarshalJSON(data []byte) error {
+	var cfg map[string]any
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return err
+	}
+	*e = entryFromMap(cfg)
+	return nil
+}
+
+// entryFromMap builds an entry from a decoded server object. Unknown keys,
+// and known keys whose value has an unexpected type, are kept in Extra.
+func entryFromMap(cfg map[string]any) MCPServerEntry {
+	var entry MCPServerEntry
+	for key, v := range cfg {
+		if entry.setField(key, v) {
+			if entry.present == nil {
+				entry.present = make(map[string]bool)
+			}
+			entry.present[key] = true
+			continue
+		}
+		raw, err := json.Marshal(v)
+		if err != nil {
+			continue
+		}
+		if entry.Extra == nil {
+			entry.Extra = make(map[string]json.RawMessage)
+		}
+		entry.Extra[key] = raw
+	}
+	return entry
+}
+
+// setField assigns a modeled field and reports whether key/v was consumed.
+func (e *MCPServerEntry) setField(key string, v any) bool {
+	var ok bool
+	switch key {
+	case "type":
+		e.Type, ok = v.(string)
+	case "command":
+		e.Command, ok = v.(string)
+	case "url":
+		e.URL, ok = v.(string)
+	case "enabled":
+		e.Enabled, ok = v.(bool)
+	case "args":
+		e.Args, ok = stringSlice(v)
+	case "headers":
+		e.Headers, ok = stringMap(v)
+	case "env":
+		e.Env, ok = stringMap(v)
+	}
+	return ok
+}
+
+// toMap converts the entry to a server object, omitting empty modeled fields
+// unless they were present when the entry was read.
+func (e MCPServerEntry) toMap() map[string]any {
+	cfg := make(map[string]any, len(e.Extra)+7)
+	for k, raw := range e.Extra {
+		cfg[k] = raw
+	}
+	if e.Type != "" || e.present["type"] {
+		cfg["type"] = e.Type
+	}
+	if e.Command != "" || e.present["command"] {
+		cfg["command"] = e.Command
+	}
+	if len(e.Args) > 0 || e.present["args"] {
+		args := e.Args
+		if args == nil {
+			args = []string{}
+		}
+		cfg["args"] = args
+	}
+	if e.URL != "" || e.present["url"] {
+		cfg["url"] = e.URL
+	}
+	if len(e.Headers) > 0 || e.present["headers"] {
+		cfg["headers"] = nonNilMap(e.Headers)
+	}
+	if len(e.Env) > 0 || e.present["env"] {
+		cfg["env"] = nonNilMap(e.Env)
+	}
+	if e.Enabled || e.present["enabled"] {
+		cfg["enabled"] = e.Enabled
+	}
+	return cfg
+}
+
+// nonNilMap keeps an empty map from encoding as null.
+func nonNilMap(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	return m
+}
+
+// mergeExtra returns base overlaid with override; nil when both are empty.
+func mergeExtra(base, override map[string]json.RawMessage) map[string]json.RawMessage {
+	if len(base) == 0 && len(override) == 0 {
+		return nil
+	}
+	merged := make(map[string]json.RawMessage, len(base)+len(override))
+	maps.Copy(merged, base)
+	maps.Copy(merged, override)
+	return merged
+}
+
+// stringSlice converts a decoded JSON array of strings.
+func stringSlice(v any) ([]string, bool) {
+	arr, ok := v.([]any)
+	if !ok {
+		return nil, false
+	}
+	result := make([]string, 0, len(arr))
+	for _, item := range arr {
+		s, ok := item.(string)
+		if !ok {
+			return nil, false
+		}
+		result = append(result, s)
+	}
+	return result, true
+}
+
+// stringMap converts a decoded JSON object of strings.
+func stringMap(v any) (map[string]string, bool) {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	result := make(map[string]string, len(obj))
+	for k, val := range obj {
+		s, ok := val.(string)
+		if !ok {
+			return nil, false
+		}
+		result[k] = s
+	}
+	return result, true
+}
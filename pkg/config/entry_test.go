@@ -0,0 +1,137 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestMCPServerEntry_JSONRoundTrip(t *testing.T) {
+	input := `{"type":"stdio","command":"npx","args":["-y","pkg"],"timeout":30000,"alwaysAllow":["read"]}`
+
+	var entry MCPServerEntry
+	if err := json.Unmarshal([]byte(input), &entry); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if entry.Command != "npx" || len(entry.Args) != 2 {
+		t.Errorf("modeled fields not decoded: %+v", entry)
+	}
+	if string(entry.Extra["timeout"]) != "30000" {
+		t.Errorf("Extra[timeout] = %s, want 30000", entry.Extra["timeout"])
+	}
+
+	output, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var got, want map[string]any
+	_ = json.Unmarshal(output, &got)
+	_ = json.Unmarshal([]byte(input), &want)
+	if len(got) != len(want) {
+		t.Errorf("round trip = %s, want %s", output, input)
+	}
+	for k := range want {
+		if _, ok := got[k]; !ok {
+			t.Errorf("round trip lost key %s", k)
+		}
+	}
+}
+
+func TestMCPServerEntry_UnexpectedTypeKept(t *testing.T) {
+	var entry MCPServerEntry
+	if err := json.Unmarshal([]byte(`{"command":"node","args":["a",1]}`), &entry); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if entry.Args != nil {
+		t.Errorf("Args = %v, want nil for mixed-type array", entry.Args)
+	}
+	if string(entry.Extra["args"]) != `["a",1]` {
+		t.Errorf("Extra[args] = %s, want original value preserved", entry.Extra["args"])
+	}
+}
+
+func TestWriter_UpdateMCPServerPreservesUnknownFields(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := writeTestClaudeJSON(t, tmpDir, map[string]any{
+		"mcpServers": map[string]any{
+			"ctx": map[string]any{
+				"type":    "stdio",
+				"command": "npx",
+				"args":    []any{"-y", "pkg@1.0.0"},
+				"env":     map[string]any{"TOKEN": "secret"},
+				"timeout": 30000,
+			},
+		},
+	})
+
+	writer := &Writer{homeDir: tmpDir}
+	servers, err := writer.ListMCPServerEntries()
+	if err != nil {
+		t.Fatalf("ListMCPServerEntries() error = %v", err)
+	}
+
+	entry := servers["ctx"]
+	entry.Args = []string{"-y", "pkg@2.0.0"}
+	if err := writer.UpdateMCPServer("ctx", entry); err != nil {
+		t.Fatalf("UpdateMCPServer() error = %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	var config map[string]any
+	_ = json.Unmarshal(data, &config)
+	server := config["mcpServers"].(map[string]any)["ctx"].(map[string]any)
+
+	if server["timeout"] != float64(30000) {
+		t.Errorf("timeout = %v, want 30000 preserved", server["timeout"])
+	}
+	if server["env"].(map[string]any)["TOKEN"] != "secret" {
+		t.Error("env should be preserved")
+	}
+	if server["args"].([]any)[1] != "pkg@2.0.0" {
+		t.Errorf("args = %v, want updated version", server["args"])
+	}
+}
+
+func TestWriter_UpdateMCPServerNotFound(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestClaudeJSON(t, tmpDir, map[string]any{"mcpServers": map[string]any{}})
+
+	writer := &Writer{homeDir: tmpDir}
+	if err := writer.UpdateMCPServer("missing", MCPServerEntry{Command: "npx"}); err == nil {
+		t.Error("UpdateMCPServer() should fail for a missing server")
+	}
+
+	backups, _ := writer.ListBackups()
+	if len(backups) != 0 {
+		t.Error("a failed update should not write or back up the file")
+	}
+}
+
+func TestMCPServerEntry_EmptyFieldsKept(t *testing.T) {
+	input := `{"command":"npx","args":[],"headers":{},"env":{},"enabled":false}`
+
+	var entry MCPServerEntry
+	if err := json.Unmarshal([]byte(input), &entry); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	output, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var got, want map[string]any
+	_ = json.Unmarshal(output, &got)
+	_ = json.Unmarshal([]byte(input), &want)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip = %s, want %s", output, input)
+	}
+
+	// Entries built in code still omit empty fields.
+	output, _ = json.Marshal(MCPServerEntry{Command: "npx"})
+	if string(output) != `{"command":"npx"}` {
+		t.Errorf("Marshal() = %s, want only command", output)
+	}
+}
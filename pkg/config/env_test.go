@@ -0,0 +1,95 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseEnvFile(t *testing.T) {
+	content := `# comment
+GITHUB_TOKEN=ghp_abc123
+export DATABASE_URL="postgres://u:p@localhost/db"
+
+SINGLE='a b c'
+ESCAPED="line1\nline2"
+INLINE=value # trailing comment
+EMPTY=
+`
+	env, err := ParseEnvFile(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("ParseEnvFile() error = %v", err)
+	}
+
+	want := map[string]string{
+		"GITHUB_TOKEN": "ghp_abc123",
+		"DATABASE_URL": "postgres://u:p@localhost/db",
+		"SINGLE":       "a b c",
+		"ESCAPED":      "line1\nline2",
+		"INLINE":       "value",
+		"EMPTY":        "",
+	}
+	if len(env) != len(want) {
+		t.Errorf("got %d variables, want %d", len(env), len(want))
+	}
+	for k, v := range want {
+		if env[k] != v {
+			t.Errorf("env[%s] = %q, want %q", k, env[k], v)
+		}
+	}
+}
+
+func TestParseEnvFile_Invalid(t *testing.T) {
+	if _, err := ParseEnvFile(strings.NewReader("OK=1\nnot an assignment\n")); err == nil {
+		t.Error("ParseEnvFile() should fail on a line without '='")
+	}
+}
+
+func TestParseEnvAssignment(t *testing.T) {
+	key, value, err := ParseEnvAssignment("API_KEY=a=b")
+	if err != nil {
+		t.Fatalf("ParseEnvAssignment() error = %v", err)
+	}
+	if key != "API_KEY" || value != "a=b" {
+		t.Errorf("got %s=%s, want API_KEY=a=b", key, value)
+	}
+
+	for _, bad := range []string{"NOVALUE", "=value", "BAD KEY=x"} {
+		if _, _, err := ParseEnvAssignment(bad); err == nil {
+			t.Errorf("ParseEnvAssignment(%q) should fail", bad)
+		}
+	}
+}
+
+func TestWriter_EnvRoundTrip(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestClaudeJSON(t, tmpDir, map[string]any{})
+
+	writer := &Writer{homeDir: tmpDir}
+	entry := MCPServerEntry{
+		Type:    TypeStdio,
+		Command: "npx",
+		Args:    []string{"-y", "@modelcontextprotocol/server-github"},
+		Env:     map[string]string{"GITHUB_TOKEN": "secret"},
+	}
+	if err := writer.AddMCPServer("github", entry); err != nil {
+		t.Fatalf("AddMCPServer() error = %v", err)
+	}
+
+	servers, err := writer.ListMCPServerEntries()
+	if err != nil {
+		t.Fatalf("ListMCPServerEntries() error = %v", err)
+	}
+	if got := servers["github"].Env["GITHUB_TOKEN"]; got != "secret" {
+		t.Errorf("Env[GITHUB_TOKEN] = %q, want secret", got)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(tmpDir, ".claude.json"))
+	if !strings.Contains(string(data), `"env"`) {
+		t.Error("env block should be written to claude.json")
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// defaultIndent matches both json.MarshalIndent and JSON.stringify(v, null, 2).
+const defaultIndent = "  "
+
+// pathSep separates object keys in layout paths. It is a control character
+// that does not appear in real config keys.
+const pathSep = "\x1f"
+
+// jsonLayout captures the formatting of a JSON document — indentation,
+// trailing newline and the key order of every object — so that a modified
+// document can be written back with a minimal, reviewable diff.
+type jsonLayout struct {
+	indent  string
+	newline bool
+	order   map[string][]string // object path -> keys in original order
+}
+
+// parseLayout records the layout of data. Invalid JSON yields the default
+// layout; the caller reports the parse error separately.
+func parseLayout(data []byte) jsonLayout {
+	layout := jsonLayout{
+		indent:  detectIndent(data),
+		newline: bytes.HasSuffix(data, []byte("\n")),
+		order:   make(map[string][]string),
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	_ = recordOrder(dec, "", layout.order)
+	return layout
+}
+
+// detectIndent returns the leading whitespace of the first indented line.
+func detectIndent(data []byte) string {
+	for line := range strings.Lines(string(data)) {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed == "" || len(trimmed) == len(line) {
+			continue
+		}
+		return line[:len(line)-len(trimmed)]
+	}
+	return defaultIndent
+}
+
+func recordOrder(dec *json.Decoder, path string, order map[string][]string) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return nil
+	}
+
+	switch delim {
+	case '{':
+		var keys []string
+		for dec.More() {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			key, _ := keyTok.(string)
+			keys = append(keys, key)
+			if err := recordOrder(dec, path+pathSep+key, order); err != nil {
+				return err
+			}
+		}
+		order[path] = keys
+	case '[':
+		for i := 0; dec.More(); i++ {
+			if err := recordOrder(dec, path+pathSep+"["+strconv.Itoa(i)+"]", order); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Consume the closing delimiter.
+	_, err = dec.Token()
+	return err
+}
+
+// decodeJSONObject decodes a JSON object keeping numbers as json.Number, so
+// values this tool does not touch are written back exactly as they were.
+func decodeJSONObject(data []byte) (map[string]any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var obj map[string]any
+	if err := dec.Decode(&obj); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("unexpected data after top-level object")
+	}
+	return obj, nil
+}
+
+// marshal encodes v using the recorded layout. Keys present in the original
+// document keep their position; new keys are appended after them.
+func (l jsonLayout) marshal(v any) ([]byte, error) {
+	// Normalize arbitrary Go values (structs, typed maps, RawMessage) to the
+	// generic representation so every object goes through the ordered encoder.
+	raw, err := encodeNoEscape(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var generic any
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := l.encode(&buf, generic, "", 0); err != nil {
+		return nil, err
+	}
+	if l.newline {
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+func (l jsonLayout) encode(buf *bytes.Buffer, v any, path string, depth int) error {
+	switch val := v.(type) {
+	case map[string]any:
+		if len(val) == 0 {
+			buf.WriteString("{}")
+			return nil
+		}
+		buf.WriteByte('{')
+		for i, key := range l.orderedKeys(path, val) {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			l.writeIndent(buf, depth+1)
+			keyJSON, err := encodeNoEscape(key)
+			if err != nil {
+				return err
+			}
+			buf.Write(keyJSON)
+			buf.WriteString(": ")
+			if err := l.encode(buf, val[key], path+pathSep+key, depth+1); err != nil {
+				return err
+			}
+		}
+		l.writeIndent(buf, depth)
+		buf.WriteByte('}')
+	case []any:
+		if len(val) == 0 {
+			buf.WriteString("[]")
+			return nil
+		}
+		buf.WriteByte('[')
+		for i, item := range val {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			l.writeIndent(buf, depth+1)
+			if err := l.encode(buf, item, path+pathSep+"["+strconv.Itoa(i)+"]", depth+1); err != nil {
+				return err
+			}
+		}
+		l.writeIndent(buf, depth)
+		buf.WriteByte(']')
+	default:
+		scalar, err := encodeNoEscape(val)
+		if err != nil {
+			return err
+		}
+		buf.Write(scalar)
+	}
+	return nil
+}
+
+func (l jsonLayout) writeIndent(buf *bytes.Buffer, depth int) {
+	buf.WriteByte('\n')
+	for range depth {
+		buf.WriteString(l.indent)
+	}
+}
+
+// newKeyRank orders keys that were not in the original document, so newly
+// added servers read naturally ("type" and "command" before "args").
+var newKeyRank = map[string]int{
+	"type": 1, "command": 2, "args": 3, "url": 4, "headers": 5, "env": 6, "enabled": 7,
+}
+
+// orderedKeys returns the keys of obj: originally present keys first, in
+// their original order, followed by new keys (well-known server fields
+// first, the rest alphabetically).
+func (l jsonLayout) orderedKeys(path string, obj map[string]any) []string {
+	keys := make([]string, 0, len(obj))
+	seen := make(map[string]bool, len(obj))
+	for _, key := range l.order[path] {
+		if _, ok := obj[key]; ok && !seen[key] {
+			keys = append(keys, key)
+			seen[key] = true
+		}
+	}
+
+	var added []string
+	for key := range obj {
+		if !seen[key] {
+			added = append(added, key)
+		}
+	}
+	sort.Slice(added, func(i, j int) bool {
+		ri, rj := rankOf(added[i]), rankOf(added[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return added[i] < added[j]
+	})
+	return append(keys, added...)
+}
+
+func rankOf(key string) int {
+	if rank, ok := newKeyRank[key]; ok {
+		return rank
+	}
+	return len(newKeyRank) + 1
+}
+
+// encodeNoEscape marshals v without HTML escaping, matching how Claude Code
+// (JSON.stringify) writes strings such as URLs containing '&'.
+func encodeNoEscape(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
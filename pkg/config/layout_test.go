@@ -0,0 +1,145 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriter_ProjectFileKeepsLayout(t *testing.T) {
+	project := t.TempDir()
+	original := "{\n" +
+		"\t\"mcpServers\": {\n" +
+		"\t\t\"zeta\": {\n" +
+		"\t\t\t\"url\": \"https://example.com/mcp?a=1&b=2\",\n" +
+		"\t\t\t\"type\": \"http\",\n" +
+		"\t\t\t\"timeout\": 1.50\n" +
+		"\t\t},\n" +
+		"\t\t\"alpha\": {\n" +
+		"\t\t\t\"command\": \"npx\"\n" +
+		"\t\t}\n" +
+		"\t},\n" +
+		"\t\"$comment\": \"shared servers\"\n" +
+		"}\n"
+	path := filepath.Join(project, ".mcp.json")
+	if err := os.WriteFile(path, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	writer := (&Writer{homeDir: t.TempDir(), projectDir: project}).WithScope(ScopeProject)
+	entry := MCPServerEntry{Type: TypeStdio, Command: "uvx", Args: []string{"serena"}}
+	if err := writer.AddMCPServer("middle", entry); err != nil {
+		t.Fatalf("AddMCPServer() error = %v", err)
+	}
+
+	want := "{\n" +
+		"\t\"mcpServers\": {\n" +
+		"\t\t\"zeta\": {\n" +
+		"\t\t\t\"url\": \"https://example.com/mcp?a=1&b=2\",\n" +
+		"\t\t\t\"type\": \"http\",\n" +
+		"\t\t\t\"timeout\": 1.50\n" +
+		"\t\t},\n" +
+		"\t\t\"alpha\": {\n" +
+		"\t\t\t\"command\": \"npx\"\n" +
+		"\t\t},\n" +
+		"\t\t\"middle\": {\n" +
+		"\t\t\t\"type\": \"stdio\",\n" +
+		"\t\t\t\"command\": \"uvx\",\n" +
+		"\t\t\t\"args\": [\n" +
+		"\t\t\t\t\"serena\"\n" +
+		"\t\t\t]\n" +
+		"\t\t}\n" +
+		"\t},\n" +
+		"\t\"$comment\": \"shared servers\"\n" +
+		"}\n"
+	got, _ := os.ReadFile(path)
+	if string(got) != want {
+		t.Errorf("layout not preserved:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+
+	// Removing the added server restores the original bytes.
+	if err := writer.RemoveMCPServer("middle"); err != nil {
+		t.Fatalf("RemoveMCPServer() error = %v", err)
+	}
+	got, _ = os.ReadFile(path)
+	if string(got) != original {
+		t.Errorf("remove should restore the original file:\n%s", got)
+	}
+}
+
+func TestWriter_UpdateKeepsServerKeyOrder(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, ".claude.json")
+	original := `{
+  "numStartups": 42,
+  "mcpServers": {
+    "ctx": {
+      "command": "npx",
+      "args": ["-y", "pkg@1.0.0"],
+      "type": "stdio"
+    }
+  }
+}`
+	if err := os.WriteFile(path, []byte(original), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	writer := &Writer{homeDir: tmpDir}
+	if err := writer.UpdateMCPServer("ctx", MCPServerEntry{
+		Type: "stdio", Command: "npx", Args: []string{"-y", "pkg@2.0.0"},
+	}); err != nil {
+		t.Fatalf("UpdateMCPServer() error = %v", err)
+	}
+
+	want := `{
+  "numStartups": 42,
+  "mcpServers": {
+    "ctx": {
+      "command": "npx",
+      "args": [
+        "-y",
+        "pkg@2.0.0"
+      ],
+      "type": "stdio"
+    }
+  }
+}`
+	got, _ := os.ReadFile(path)
+	if string(got) != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFindProjectMCPFile(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "services", "api")
+	if err := os.MkdirAll(filepath.Join(root, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// No .mcp.json yet: create at the repository root.
+	if got, want := FindProjectMCPFile(sub), filepath.Join(root, ".mcp.json"); got != want {
+		t.Errorf("FindProjectMCPFile() = %s, want %s", got, want)
+	}
+
+	// The nearest file wins.
+	nested := filepath.Join(root, "services", ".mcp.json")
+	if err := os.WriteFile(nested, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := FindProjectMCPFile(sub); got != nested {
+		t.Errorf("FindProjectMCPFile() = %s, want %s", got, nested)
+	}
+
+	reader := &Reader{homeDir: t.TempDir(), projectDir: sub}
+	paths := reader.GetConfigPaths()
+	if paths[len(paths)-1] != nested {
+		t.Errorf("GetConfigPaths() should report %s, got %v", nested, paths)
+	}
+}
@@ -0,0 +1,22 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"path/filepath"
+
+	"github.com/gizzahub/gzh-cli-mcp-plugin/internal/fsutil"
+)
+
+// lockPath returns the advisory lock file guarding path. Lock files live in
+// mcp-plugin's own state directory so nothing is created next to files that
+// Claude Code manages.
+func (w *Writer) lockPath(path string) string {
+	return fsutil.LockFile(filepath.Join(w.StateDir(), "locks"), path)
+}
+
+// lock acquires the advisory lock for path.
+func (w *Writer) lock(path string) (unlock func(), err error) {
+	return fsutil.Lock(w.lockPath(path), path)
+}
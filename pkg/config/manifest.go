@@ -0,0 +1,343 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Manifest declares the desired MCP servers per scope and the desired plugin
+// states. It is written in YAML or JSON:
+//
+//	servers:
+//	  user:
+//	    context7:
+//	      command: npx
+//	      args: ["-y", "@upstash/context7-mcp"]
+//	plugins:
+//	  serena@claude-plugins: true
+type Manifest struct {
+	Servers map[Scope]map[string]MCPServerEntry
+	Plugins map[string]bool
+}
+
+// manifestFile is the on-disk form of a Manifest.
+type manifestFile struct {
+	Servers map[string]map[string]MCPServerEntry `json:"servers"`
+	Plugins map[string]bool                      `json:"plugins"`
+}
+
+// LoadManifest reads and validates a manifest file.
+func LoadManifest(path string) (*Manifest, error) {
+	// #nosec G304 -- path is an intentional user-provided CLI path
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	m, err := ParseManifest(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", path, err)
+	}
+	return m, nil
+}
+
+// ParseManifest parses a YAML or JSON manifest.
+func ParseManifest(data []byte) (*Manifest, error) {
+	// YAML is a superset of JSON. Going through JSON lets MCPServerEntry keep
+	// unmodeled fields in Extra exactly as it does for Claude's own files.
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return &Manifest{}, nil
+	}
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported manifest structure: %w", err)
+	}
+
+	var file manifestFile
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&file); err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{Servers: make(map[Scope]map[string]MCPServerEntry), Plugins: file.Plugins}
+	for name, servers := range file.Servers {
+		scope, err := ParseScope(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := m.Servers[scope]; dup {
+			return nil, fmt.Errorf("scope %s is declared more than once", scope)
+		}
+		for server, entry := range servers {
+			if entry.Command == "" && entry.URL == "" {
+				return nil, fmt.Errorf("server '%s' in %s scope needs a command or url", server, scope)
+			}
+		}
+		if servers == nil {
+			servers = map[string]MCPServerEntry{}
+		}
+		m.Servers[scope] = servers
+	}
+	return m, nil
+}
+
+// Action is the kind of change a plan step makes.
+type Action string
+
+// Plan actions.
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// Change is one step needed to converge the configuration to a Manifest.
+// Server changes carry a Scope; plugin changes do not.
+type Change struct {
+	Action Action        `json:"action"`
+	Kind   string        `json:"kind"` // "server" or "plugin"
+	Scope  Scope         `json:"scope,omitempty"`
+	Name   string        `json:"name"`
+	Fields []FieldChange `json:"fields,omitempty"`
+
+	entry   MCPServerEntry
+	enabled bool
+}
+
+// Entry returns the server entry a create or update change writes.
+func (c Change) Entry() MCPServerEntry {
+	return c.entry
+}
+
+// Change kinds.
+const (
+	KindServer = "server"
+	KindPlugin = "plugin"
+)
+
+// Plan compares m with the current configuration and returns the changes
+// that Apply would make. Servers the manifest does not list are deleted only
+// with prune, and only in scopes the manifest declares. Likewise, prune
+// disables enabled plugins missing from a declared plugins section, and
+// removes the modeled fields (args, env vars, headers, ...) a listed server
+// has but the manifest omits; without prune they are left as they are.
+func (w *Writer) Plan(m *Manifest, prune bool) ([]Change, error) {
+	var changes []Change
+
+	for _, scope := range WritableScopes {
+		desired, declared := m.Servers[scope]
+		if !declared {
+			continue
+		}
+		current, err := w.WithScope(scope).ListMCPServerEntries()
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, planServers(scope, current, desired, prune)...)
+	}
+
+	if m.Plugins != nil {
+		current, err := w.ListPlugins()
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, planPlugins(current, m.Plugins, prune)...)
+	}
+
+	return changes, nil
+}
+
+func planServers(scope Scope, current, desired map[string]MCPServerEntry, prune bool) []Change {
+	var changes []Change
+	for _, name := range slices.Sorted(maps.Keys(desired)) {
+		entry := desired[name]
+		existing, exists := current[name]
+		switch {
+		case !exists:
+			changes = append(changes, Change{
+				Action: ActionCreate, Kind: KindServer, Scope: scope, Name: name,
+				Fields: DiffEntries(MCPServerEntry{}, entry), entry: entry,
+			})
+		default:
+			if !prune {
+				entry = overlayEntry(existing, entry)
+			}
+			fields := diffFields(flattenEntry(existing), flattenEntry(entry), true)
+			if len(fields) > 0 {
+				changes = append(changes, Change{
+					Action: ActionUpdate, Kind: KindServer, Scope: scope, Name: name,
+					Fields: fields, entry: entry,
+				})
+			}
+		}
+	}
+
+	if !prune {
+		return changes
+	}
+	for _, name := range slices.Sorted(maps.Keys(current)) {
+		if _, wanted := desired[name]; !wanted {
+			changes = append(changes, Change{
+				Action: ActionDelete, Kind: KindServer, Scope: scope, Name: name,
+				Fields: DiffEntries(current[name], MCPServerEntry{}),
+			})
+		}
+	}
+	return changes
+}
+
+// overlayEntry returns existing with the fields desired sets replacing its
+// own. Env vars and headers are merged per key.
+func overlayEntry(existing, desired MCPServerEntry) MCPServerEntry {
+	merged := existing
+	if desired.Type != "" || desired.present["type"] {
+		merged.Type = desired.Type
+	}
+	if desired.Command != "" || desired.present["command"] {
+		merged.Command = desired.Command
+	}
+	if len(desired.Args) > 0 || desired.present["args"] {
+		merged.Args = desired.Args
+	}
+	if desired.URL != "" || desired.present["url"] {
+		merged.URL = desired.URL
+	}
+	if desired.Enabled || desired.present["enabled"] {
+		merged.Enabled = desired.Enabled
+	}
+	merged.Headers = mergeStrings(existing.Headers, desired.Headers)
+	merged.Env = mergeStrings(existing.Env, desired.Env)
+	merged.Extra = mergeExtra(existing.Extra, desired.Extra)
+	merged.present = make(map[string]bool, len(existing.present)+len(desired.present))
+	maps.Copy(merged.present, existing.present)
+	maps.Copy(merged.present, desired.present)
+	return merged
+}
+
+// mergeStrings returns base overlaid with override; nil when both are empty.
+func mergeStrings(base, override map[string]string) map[string]string {
+	if len(base) == 0 && len(override) == 0 {
+		return nil
+	}
+	merged := make(map[string]string, len(base)+len(override))
+	maps.Copy(merged, base)
+	maps.Copy(merged, override)
+	return merged
+}
+
+func planPlugins(current, desired map[string]bool, prune bool) []Change {
+	var changes []Change
+	for _, id := range slices.Sorted(maps.Keys(desired)) {
+		enabled := desired[id]
+		was, exists := current[id]
+		if exists && was == enabled {
+			continue
+		}
+		change := Change{Action: ActionUpdate, Kind: KindPlugin, Name: id, enabled: enabled}
+		if !exists {
+			change.Action = ActionCreate
+		}
+		change.Fields = []FieldChange{{Field: "enabled", New: strconv.FormatBool(enabled)}}
+		if exists {
+			change.Fields[0].Old = strconv.FormatBool(was)
+		}
+		changes = append(changes, change)
+	}
+
+	if !prune {
+		return changes
+	}
+	for _, id := range slices.Sorted(maps.Keys(current)) {
+		if _, wanted := desired[id]; !wanted && current[id] {
+			changes = append(changes, Change{
+				Action: ActionUpdate, Kind: KindPlugin, Name: id,
+				Fields: []FieldChange{{Field: "enabled", Old: "true", New: "false"}},
+			})
+		}
+	}
+	return changes
+}
+
+// Apply performs changes in order, grouped by the file they touch: each
+// file is changed in one atomic write with one backup, so a rollback
+// restores the state before the apply. On error, the files written so far
+// are kept and the number of changes they hold is returned.
+func (w *Writer) Apply(changes []Change) (int, error) {
+	type fileChanges struct {
+		loc     serverLocation
+		changes []Change
+	}
+	var files []*fileChanges
+	byPath := make(map[string]*fileChanges)
+	for _, c := range changes {
+		loc := w.changeLocation(c)
+		f, ok := byPath[loc.path]
+		if !ok {
+			f = &fileChanges{loc: loc}
+			byPath[loc.path] = f
+			files = append(files, f)
+		}
+		f.changes = append(f.changes, c)
+	}
+
+	applied := 0
+	for _, f := range files {
+		err := w.modifyJSONFile(f.loc.path, f.loc.label, f.loc.create, func(config map[string]any) error {
+			for _, c := range f.changes {
+				if err := w.applyChange(config, c); err != nil {
+					return fmt.Errorf("failed to %s %s '%s': %w", c.Action, c.Kind, c.Name, err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return applied, err
+		}
+		applied += len(f.changes)
+	}
+	return applied, nil
+}
+
+// changeLocation is the file and object a change is made in.
+func (w *Writer) changeLocation(c Change) serverLocation {
+	if c.Kind == KindPlugin {
+		return serverLocation{path: filepath.Join(w.ClaudeDir(), "settings.json"), label: "settings"}
+	}
+	return w.WithScope(c.Scope).location()
+}
+
+// applyChange makes c in config, the parsed content of its file.
+func (w *Writer) applyChange(config map[string]any, c Change) error {
+	if c.Kind == KindPlugin {
+		setPlugin(config, c.Name, c.enabled)
+		return nil
+	}
+
+	scoped := w.WithScope(c.Scope)
+	mcpServers := objectAt(config, scoped.location().keys, true)
+	switch c.Action {
+	case ActionCreate:
+		return scoped.addServer(mcpServers, c.Name, c.entry)
+	case ActionUpdate:
+		return scoped.updateServer(mcpServers, c.Name, c.entry)
+	case ActionDelete:
+		return scoped.removeServer(mcpServers, c.Name)
+	default:
+		return fmt.Errorf("unknown action %q", c.Action)
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseManifest(t *testing.T) {
+	m, err := ParseManifest([]byte(`
+servers:
+  User:
+    ctx:
+      command: npx
+      args: ["-y", "ctx-mcp"]
+      timeout: 30
+plugins:
+  serena@claude-plugins: true
+`))
+	if err != nil {
+		t.Fatalf("ParseManifest() error = %v", err)
+	}
+	entry := m.Servers[ScopeUser]["ctx"]
+	if entry.Command != "npx" || len(entry.Args) != 2 {
+		t.Errorf("entry = %+v", entry)
+	}
+	if string(entry.Extra["timeout"]) != "30" {
+		t.Errorf("unmodeled field not kept: %v", entry.Extra)
+	}
+	if !m.Plugins["serena@claude-plugins"] {
+		t.Errorf("plugins = %v", m.Plugins)
+	}
+
+	invalid := map[string]string{
+		"unknown scope":  "servers:\n  plugin:\n    a:\n      command: x\n",
+		"unknown key":    "server: {}\n",
+		"missing target": "servers:\n  user:\n    a:\n      type: stdio\n",
+	}
+	for name, doc := range invalid {
+		if _, err := ParseManifest([]byte(doc)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestWriter_PlanAndApply(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := writeTestClaudeJSON(t, tmpDir, map[string]any{
+		"mcpServers": map[string]any{
+			"keep":   map[string]any{"command": "npx", "args": []any{"keep@1"}, "timeout": 5},
+			"update": map[string]any{"command": "npx", "args": []any{"pkg@1"}},
+			"stale":  map[string]any{"url": "https://example.com/mcp"},
+		},
+	})
+	settings := filepath.Join(tmpDir, ".claude", "settings.json")
+	if err := os.MkdirAll(filepath.Dir(settings), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(settings, []byte(`{"enabledPlugins": {"old@m": true}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := ParseManifest([]byte(`
+servers:
+  user:
+    keep: {command: npx, args: ["keep@1"]}
+    update: {command: npx, args: ["pkg@2"]}
+    add: {type: http, url: "https://example.com/new"}
+plugins:
+  new@m: true
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writer := &Writer{homeDir: tmpDir}
+	changes, err := writer.Plan(m, true)
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+
+	var got []string
+	for _, c := range changes {
+		got = append(got, string(c.Action)+" "+c.Kind+" "+c.Name)
+	}
+	want := []string{
+		"create server add",
+		"update server update",
+		"delete server stale",
+		"create plugin new@m",
+		"update plugin old@m",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("Plan() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if f := changes[1].Fields; len(f) != 1 || f[0].Field != "args" || f[0].New != `["pkg@2"]` {
+		t.Errorf("update fields = %+v", f)
+	}
+
+	if n, err := writer.Apply(changes); err != nil || n != len(changes) {
+		t.Fatalf("Apply() = %d, %v", n, err)
+	}
+
+	// Converged: a second plan is empty and unmodeled fields survived.
+	changes, err = writer.Plan(m, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("second Plan() = %+v, want no changes", changes)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), `"timeout": 5`) {
+		t.Errorf("unmodeled field lost:\n%s", data)
+	}
+	plugins, _ := writer.ListPlugins()
+	if plugins["old@m"] || !plugins["new@m"] {
+		t.Errorf("plugins = %v", plugins)
+	}
+}
+
+func TestWriter_PlanPrunesDeclaredScopesOnly(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestClaudeJSON(t, tmpDir, map[string]any{
+		"mcpServers": map[string]any{"user-server": map[string]any{"command": "npx"}},
+	})
+
+	m, err := ParseManifest([]byte("servers:\n  local: {}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	changes, err := (&Writer{homeDir: tmpDir, projectDir: t.TempDir()}).Plan(m, true)
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("Plan() = %+v, want user scope untouched", changes)
+	}
+}
+
+func TestWriter_PlanKeepsOmittedFields(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := writeTestClaudeJSON(t, tmpDir, map[string]any{
+		"mcpServers": map[string]any{
+			"api": map[string]any{
+				"command": "npx", "args": []any{"api@1"},
+				"env":     map[string]any{"API_KEY": "secret", "REGION": "eu"},
+				"headers": map[string]any{"X-Team": "core"},
+			},
+		},
+	})
+
+	m, err := ParseManifest([]byte(`
+servers:
+  user:
+    api: {command: npx, args: ["api@2"], env: {REGION: us}}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	writer := &Writer{homeDir: tmpDir}
+
+	changes, err := writer.Plan(m, false)
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("Plan() = %+v, want one update", changes)
+	}
+	var fields []string
+	for _, f := range changes[0].Fields {
+		fields = append(fields, f.Field)
+	}
+	if want := []string{"args", "env.REGION"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("update fields = %v, want %v", fields, want)
+	}
+	if _, err := writer.Apply(changes); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	for _, kept := range []string{`"API_KEY": "secret"`, `"X-Team": "core"`, `"REGION": "us"`} {
+		if !strings.Contains(string(data), kept) {
+			t.Errorf("missing %s after apply:\n%s", kept, data)
+		}
+	}
+
+	// With prune, fields the manifest omits are removed.
+	changes, err = writer.Plan(m, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields = nil
+	for _, f := range changes[0].Fields {
+		if f.New != "" {
+			t.Errorf("prune plans %s = %q, want a deletion", f.Field, f.New)
+		}
+		fields = append(fields, f.Field)
+	}
+	if want := []string{"headers.X-Team", "env.API_KEY"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("prune fields = %v, want %v", fields, want)
+	}
+}
+
+func TestWriter_ApplyManyChangesRollsBack(t *testing.T) {
+	tmpDir := t.TempDir()
+	servers := make(map[string]any)
+	for i := range 15 {
+		servers[fmt.Sprintf("old-%02d", i)] = map[string]any{"command": "npx", "args": []any{"old"}}
+	}
+	path := writeTestClaudeJSON(t, tmpDir, map[string]any{"mcpServers": servers})
+	original, _ := os.ReadFile(path)
+
+	var doc strings.Builder
+	doc.WriteString("servers:\n  user:\n")
+	for i := range 15 {
+		fmt.Fprintf(&doc, "    new-%02d: {command: npx, args: [new]}\n", i)
+	}
+	m, err := ParseManifest([]byte(doc.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writer := &Writer{homeDir: tmpDir}
+	changes, err := writer.Plan(m, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) <= DefaultMaxBackups {
+		t.Fatalf("Plan() = %d changes, want more than %d", len(changes), DefaultMaxBackups)
+	}
+	if n, err := writer.Apply(changes); err != nil || n != len(changes) {
+		t.Fatalf("Apply() = %d, %v", n, err)
+	}
+	if backups, _ := writer.ListBackups(); len(backups) != 1 {
+		t.Errorf("Apply() took %d backups, want one for the one file", len(backups))
+	}
+
+	if _, err := writer.Rollback(""); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if data, _ := os.ReadFile(path); !bytes.Equal(data, original) {
+		t.Errorf("after rollback:\n%s\nwant\n%s", data, original)
+	}
+}
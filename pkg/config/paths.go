@@ -0,0 +1,79 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// ConfigDirEnv is the environment variable Claude Code reads to relocate its
+// configuration directory. When set, .claude.json lives inside it as well.
+const ConfigDirEnv = "CLAUDE_CONFIG_DIR"
+
+// Option configures where a Reader or Writer finds Claude Code's files.
+type Option func(*options)
+
+type options struct {
+	homeDir   string
+	configDir string
+}
+
+// WithHomeDir treats dir as the user's home directory: ~/.claude.json and
+// ~/.claude are resolved inside it. It takes precedence over CLAUDE_CONFIG_DIR.
+func WithHomeDir(dir string) Option {
+	return func(o *options) { o.homeDir = dir }
+}
+
+// WithConfigDir uses dir in place of ~/.claude, like CLAUDE_CONFIG_DIR. It
+// takes precedence over both the environment variable and WithHomeDir.
+func WithConfigDir(dir string) Option {
+	return func(o *options) { o.configDir = dir }
+}
+
+// resolveDirs applies opts, then CLAUDE_CONFIG_DIR, then the real home
+// directory. An empty configDir means the default ~/.claude layout.
+func resolveDirs(opts []Option) (homeDir, configDir string) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.configDir == "" && o.homeDir == "" {
+		o.configDir = os.Getenv(ConfigDirEnv)
+	}
+	if o.homeDir == "" {
+		home, err := os.UserHomeDir()
+		if err == nil {
+			o.homeDir = home
+		}
+	}
+	return absDir(o.homeDir), absDir(o.configDir)
+}
+
+func absDir(dir string) string {
+	if dir == "" {
+		return ""
+	}
+	if abs, err := filepath.Abs(dir); err == nil {
+		return abs
+	}
+	return dir
+}
+
+// claudeDir returns Claude Code's configuration directory (~/.claude).
+func claudeDir(homeDir, configDir string) string {
+	if configDir != "" {
+		return configDir
+	}
+	return filepath.Join(homeDir, ".claude")
+}
+
+// claudeJSONPath returns the location of .claude.json.
+func claudeJSONPath(homeDir, configDir string) string {
+	if configDir != "" {
+		return filepath.Join(configDir, ".claude.json")
+	}
+	return filepath.Join(homeDir, ".claude.json")
+}
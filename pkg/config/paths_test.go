@@ -0,0 +1,94 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveDirs(t *testing.T) {
+	home := t.TempDir()
+	envDir := t.TempDir()
+	flagDir := t.TempDir()
+	t.Setenv("HOME", home)
+
+	tests := []struct {
+		name       string
+		env        string
+		opts       []Option
+		wantClaude string
+		wantJSON   string
+	}{
+		{
+			name:       "default",
+			wantClaude: filepath.Join(home, ".claude"),
+			wantJSON:   filepath.Join(home, ".claude.json"),
+		},
+		{
+			name:       "env",
+			env:        envDir,
+			wantClaude: envDir,
+			wantJSON:   filepath.Join(envDir, ".claude.json"),
+		},
+		{
+			name:       "home option overrides env",
+			env:        envDir,
+			opts:       []Option{WithHomeDir(flagDir)},
+			wantClaude: filepath.Join(flagDir, ".claude"),
+			wantJSON:   filepath.Join(flagDir, ".claude.json"),
+		},
+		{
+			name:       "config dir option overrides env",
+			env:        envDir,
+			opts:       []Option{WithConfigDir(flagDir)},
+			wantClaude: flagDir,
+			wantJSON:   filepath.Join(flagDir, ".claude.json"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(ConfigDirEnv, tt.env)
+
+			reader := NewReader(tt.opts...)
+			if got := reader.ClaudeDir(); got != tt.wantClaude {
+				t.Errorf("Reader.ClaudeDir() = %s, want %s", got, tt.wantClaude)
+			}
+			if got := reader.GetConfigPaths()[0]; got != tt.wantJSON {
+				t.Errorf("claude.json = %s, want %s", got, tt.wantJSON)
+			}
+
+			writer := NewWriter(tt.opts...)
+			if got := writer.ServersPath(); got != tt.wantJSON {
+				t.Errorf("Writer.ServersPath() = %s, want %s", got, tt.wantJSON)
+			}
+			if got, want := writer.BackupDir(), filepath.Join(tt.wantClaude, "mcp-plugin", "backups"); got != want {
+				t.Errorf("Writer.BackupDir() = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestWriter_ConfigDir(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv(ConfigDirEnv, "")
+	if err := os.WriteFile(filepath.Join(configDir, ".claude.json"), []byte("{}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	writer := NewWriter(WithConfigDir(configDir))
+	if err := writer.AddMCPServer("ctx", MCPServerEntry{Type: TypeStdio, Command: "npx"}); err != nil {
+		t.Fatalf("AddMCPServer() error = %v", err)
+	}
+
+	servers, err := NewReader(WithConfigDir(configDir)).ListMCPServers()
+	if err != nil {
+		t.Fatalf("ListMCPServers() error = %v", err)
+	}
+	if len(servers) != 1 || servers[0].Name != "ctx" {
+		t.Errorf("ListMCPServers() = %+v, want ctx", servers)
+	}
+}
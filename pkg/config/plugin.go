@@ -0,0 +1,111 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Plugin is a Claude Code plugin as seen from settings.json and the plugin
+// cache.
+type Plugin struct {
+	ID        string   `json:"id"` // name@marketplace
+	Enabled   bool     `json:"enabled"`
+	Installed bool     `json:"installed"`      // Present in the plugin cache
+	Path      string   `json:"path,omitempty"` // The plugin's .mcp.json, if it has one
+	Servers   []string `json:"servers"`        // MCP servers the plugin provides
+}
+
+// PluginID returns the ID settings.json uses for a plugin.
+func PluginID(name, marketplace string) string {
+	return name + "@" + marketplace
+}
+
+// pluginDir is a plugin directory in the cache. The cache is laid out as
+// plugins/cache/<marketplace>/<plugin>/.
+type pluginDir struct {
+	id   string
+	path string
+}
+
+func (r *Reader) pluginCacheDirs() ([]pluginDir, error) {
+	cacheDir := filepath.Join(r.ClaudeDir(), "plugins", "cache")
+	marketplaces, err := os.ReadDir(cacheDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var dirs []pluginDir
+	for _, marketplace := range marketplaces {
+		if !marketplace.IsDir() {
+			continue
+		}
+		marketplaceDir := filepath.Join(cacheDir, marketplace.Name())
+		plugins, err := os.ReadDir(marketplaceDir)
+		if err != nil {
+			continue
+		}
+		for _, plugin := range plugins {
+			if !plugin.IsDir() {
+				continue
+			}
+			dirs = append(dirs, pluginDir{
+				id:   PluginID(plugin.Name(), marketplace.Name()),
+				path: filepath.Join(marketplaceDir, plugin.Name()),
+			})
+		}
+	}
+	return dirs, nil
+}
+
+// ListPlugins lists the plugins named in settings.json together with those
+// in the plugin cache, sorted by ID. A plugin missing from enabledPlugins is
+// disabled. A missing settings.json or cache only means fewer plugins.
+func (r *Reader) ListPlugins() ([]Plugin, error) {
+	enabled, err := r.readSettings()
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read settings: %w", err)
+	}
+
+	byID := make(map[string]*Plugin, len(enabled))
+	for id, on := range enabled {
+		byID[id] = &Plugin{ID: id, Enabled: on, Servers: []string{}}
+	}
+
+	dirs, err := r.pluginCacheDirs()
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read plugin cache: %w", err)
+	}
+	for _, dir := range dirs {
+		p, ok := byID[dir.id]
+		if !ok {
+			p = &Plugin{ID: dir.id, Servers: []string{}}
+			byID[dir.id] = p
+		}
+		p.Installed = true
+
+		mcpPath := filepath.Join(dir.path, ".mcp.json")
+		servers, err := r.parseMCPFile(mcpPath, ScopePlugin)
+		if err != nil {
+			continue
+		}
+		p.Path = mcpPath
+		for _, s := range servers {
+			p.Servers = append(p.Servers, s.Name)
+		}
+		sort.Strings(p.Servers)
+	}
+
+	plugins := make([]Plugin, 0, len(byID))
+	for _, p := range byID {
+		plugins = append(plugins, *p)
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].ID < plugins[j].ID })
+	return plugins, nil
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// setupPlugins creates a plugin cache with two plugins providing servers, a
+// plugin without servers and a settings.json naming a plugin not installed.
+func setupPlugins(t *testing.T) *Reader {
+	t.Helper()
+	home := t.TempDir()
+	write := func(rel, data string) {
+		path := filepath.Join(home, ".claude", rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("settings.json", `{"enabledPlugins": {"context7@official": true, "serena@official": false, "gone@other": true}}`)
+	write("plugins/cache/official/context7/.mcp.json", `{"context7": {"command": "npx", "args": ["-y", "@upstash/context7-mcp"]}}`)
+	write("plugins/cache/official/serena/.mcp.json", `{"mcpServers": {"serena": {"command": "uvx"}, "serena-web": {"type": "http", "url": "https://localhost:9121/mcp"}}}`)
+	write("plugins/cache/tools/linter/README.md", "no servers")
+	write("../.claude.json", `{"mcpServers": {"context7": {"command": "npx"}}}`)
+
+	return &Reader{homeDir: home, projectDir: t.TempDir()}
+}
+
+func TestReader_ListPlugins(t *testing.T) {
+	plugins, err := setupPlugins(t).ListPlugins()
+	if err != nil {
+		t.Fatalf("ListPlugins() error = %v", err)
+	}
+
+	var ids []string
+	for _, p := range plugins {
+		ids = append(ids, p.ID)
+	}
+	if want := []string{"context7@official", "gone@other", "linter@tools", "serena@official"}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("ListPlugins() IDs = %v, want %v", ids, want)
+	}
+
+	gone, linter, serena := plugins[1], plugins[2], plugins[3]
+	if !gone.Enabled || gone.Installed {
+		t.Errorf("gone = %+v, want enabled but not installed", gone)
+	}
+	if linter.Enabled || !linter.Installed || linter.Path != "" || len(linter.Servers) != 0 {
+		t.Errorf("linter = %+v, want installed, disabled, without servers", linter)
+	}
+	if serena.Enabled || !reflect.DeepEqual(serena.Servers, []string{"serena", "serena-web"}) {
+		t.Errorf("serena = %+v, want disabled with both servers", serena)
+	}
+}
+
+func TestReader_ListPlugins_NoSettings(t *testing.T) {
+	plugins, err := (&Reader{homeDir: t.TempDir()}).ListPlugins()
+	if err != nil || len(plugins) != 0 {
+		t.Errorf("ListPlugins() = %v, %v; want none", plugins, err)
+	}
+}
+
+func TestReader_ListMCPServersPluginState(t *testing.T) {
+	servers, err := setupPlugins(t).ListMCPServers()
+	if err != nil {
+		t.Fatalf("ListMCPServers() error = %v", err)
+	}
+
+	type state struct {
+		plugin  string
+		enabled bool
+	}
+	got := map[string]state{}
+	for _, s := range servers {
+		got[s.Source+"/"+s.Name] = state{s.Plugin, s.Enabled}
+	}
+	want := map[string]state{
+		"user/context7":     {"", true},
+		"plugin/context7":   {"context7@official", true},
+		"plugin/serena":     {"serena@official", false},
+		"plugin/serena-web": {"serena@official", false},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("servers = %v, want %v", got, want)
+	}
+}
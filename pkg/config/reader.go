@@ -7,123 +7,180 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 )
 
 // Reader reads Claude Code MCP configurations.
 type Reader struct {
-	homeDir string
+	homeDir    string
+	configDir  string // Overrides ~/.claude when set (CLAUDE_CONFIG_DIR)
+	projectDir string
 }
 
 // NewReader creates a new configuration reader.
-func NewReader() *Reader {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		home = ""
-	}
-	return &Reader{homeDir: home}
+func NewReader(opts ...Option) *Reader {
+	home, configDir := resolveDirs(opts)
+	return &Reader{homeDir: home, configDir: configDir, projectDir: workingDir()}
+}
+
+// ClaudeDir returns Claude Code's configuration directory.
+func (r *Reader) ClaudeDir() string {
+	return claudeDir(r.homeDir, r.configDir)
+}
+
+func (r *Reader) claudeJSONPath() string {
+	return claudeJSONPath(r.homeDir, r.configDir)
 }
 
 // GetConfigPaths returns the list of configuration file paths.
 func (r *Reader) GetConfigPaths() []string {
 	return []string{
-		filepath.Join(r.homeDir, ".claude.json"),
-		filepath.Join(r.homeDir, ".claude", "settings.json"),
-		filepath.Join(r.homeDir, ".claude", "plugins", "cache"),
+		r.claudeJSONPath(),
+		filepath.Join(r.ClaudeDir(), "settings.json"),
+		filepath.Join(r.ClaudeDir(), "plugins", "cache"),
+		r.projectMCPPath(),
 	}
 }
 
+// projectMCPPath returns the project-scope .mcp.json location, discovered by
+// walking up from the working directory.
+func (r *Reader) projectMCPPath() string {
+	return FindProjectMCPFile(r.projectDir)
+}
+
 // ListMCPServers lists all configured MCP servers.
 func (r *Reader) ListMCPServers() ([]MCPServer, error) {
 	var servers []MCPServer
 
-	// Read from ~/.claude.json
-	claudeServers, err := r.readClaudeJSON()
+	// Read user and local scopes from ~/.claude.json
+	claudeServers, project, err := r.readClaudeJSON()
 	if err == nil {
 		servers = append(servers, claudeServers...)
 	}
 
+	// Read project scope from the repository's .mcp.json
+	projectServers, err := r.parseMCPFile(r.projectMCPPath(), ScopeProject)
+	if err == nil {
+		servers = append(servers, projectServers...)
+	}
+
 	// Read from plugin cache
 	pluginServers, err := r.readPluginConfigs()
 	if err == nil {
 		servers = append(servers, pluginServers...)
 	}
 
-	// Check enabled status from settings.json (missing settings is non-fatal)
+	// Servers in user, local and project scope are loaded unless turned off
+	// for the project; plugin servers follow their plugin's entry in
+	// settings.json.
 	enabledPlugins, err := r.readSettings()
 	if err != nil {
 		enabledPlugins = map[string]bool{}
 	}
+	disabled := r.disabledServers(project)
 	for i := range servers {
-		if enabled, ok := enabledPlugins[servers[i].Name]; ok {
-			servers[i].Enabled = enabled
+		if servers[i].Plugin == "" {
+			servers[i].Enabled = !disabled[Scope(servers[i].Source)][servers[i].Name]
+			continue
 		}
+		servers[i].Enabled = enabledPlugins[servers[i].Plugin]
 	}
 
+	// Config files are maps; sort for stable output.
+	sort.SliceStable(servers, func(i, j int) bool {
+		if servers[i].Name != servers[j].Name {
+			return servers[i].Name < servers[j].Name
+		}
+		return servers[i].Source < servers[j].Source
+	})
+
 	return servers, nil
 }
 
-func (r *Reader) readClaudeJSON() ([]MCPServer, error) {
-	path := filepath.Join(r.homeDir, ".claude.json")
+// readClaudeJSON returns the user and local servers of ~/.claude.json and
+// the current project's entry.
+func (r *Reader) readClaudeJSON() ([]MCPServer, ProjectConfig, error) {
+	path := r.claudeJSONPath()
 	// #nosec G304 -- path is constructed from the user home directory
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return nil, err
+		return nil, ProjectConfig{}, err
 	}
 
 	var config ClaudeConfig
 	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, err
+		return nil, ProjectConfig{}, err
 	}
+	project := config.Projects[r.projectDir]
 
 	var servers []MCPServer
-	for _, proj := range config.Projects {
-		for name, cfg := range proj.MCPServers {
-			servers = append(servers, serverFromConfig(name, path, cfg))
+	for name, cfg := range config.MCPServers {
+		servers = append(servers, serverFromConfig(name, ScopeUser, path, cfg))
+	}
+	for name, cfg := range project.MCPServers {
+		servers = append(servers, serverFromConfig(name, ScopeLocal, path, cfg))
+	}
+
+	return servers, project, nil
+}
+
+// disabledServers returns, by scope, the names of the servers Claude Code
+// does not start in the current project: .mcp.json servers listed in
+// disabledMcpjsonServers of the user or project settings or of the project's
+// entry in ~/.claude.json, and user and local servers in that entry's
+// disabledMcpServers.
+func (r *Reader) disabledServers(project ProjectConfig) map[Scope]map[string]bool {
+	mcpjson := slices.Clone(project.DisabledMCPJSONServers)
+	root := filepath.Dir(r.projectMCPPath())
+	for _, path := range []string{
+		filepath.Join(r.ClaudeDir(), "settings.json"),
+		filepath.Join(root, ".claude", "settings.json"),
+		filepath.Join(root, ".claude", "settings.local.json"),
+	} {
+		if settings, err := readSettingsFile(path); err == nil {
+			mcpjson = append(mcpjson, settings.DisabledMCPJSONServers...)
 		}
 	}
 
-	return servers, nil
+	toSet := func(names []string) map[string]bool {
+		set := make(map[string]bool, len(names))
+		for _, name := range names {
+			set[name] = true
+		}
+		return set
+	}
+	others := toSet(project.DisabledMCPServers)
+	return map[Scope]map[string]bool{
+		ScopeUser:    others,
+		ScopeLocal:   others,
+		ScopeProject: toSet(mcpjson),
+	}
 }
 
 func (r *Reader) readPluginConfigs() ([]MCPServer, error) {
-	var servers []MCPServer
-
-	cacheDir := filepath.Join(r.homeDir, ".claude", "plugins", "cache")
-	entries, err := os.ReadDir(cacheDir)
+	dirs, err := r.pluginCacheDirs()
 	if err != nil {
 		return nil, err
 	}
 
-	for _, entry := range entries {
-		if !entry.IsDir() {
-			continue
-		}
-
-		pluginDir := filepath.Join(cacheDir, entry.Name())
-		subEntries, err := os.ReadDir(pluginDir)
+	var servers []MCPServer
+	for _, dir := range dirs {
+		parsed, err := r.parseMCPFile(filepath.Join(dir.path, ".mcp.json"), ScopePlugin)
 		if err != nil {
 			continue
 		}
-
-		for _, subEntry := range subEntries {
-			if !subEntry.IsDir() {
-				continue
-			}
-
-			mcpPath := filepath.Join(pluginDir, subEntry.Name(), ".mcp.json")
-			parsed, err := r.parsePluginMCPFile(mcpPath)
-			if err != nil {
-				continue
-			}
-			servers = append(servers, parsed...)
+		for i := range parsed {
+			parsed[i].Plugin = dir.id
 		}
+		servers = append(servers, parsed...)
 	}
 
 	return servers, nil
 }
 
-func (r *Reader) parsePluginMCPFile(mcpPath string) ([]MCPServer, error) {
-	// #nosec G304 -- path is under the known Claude plugin cache directory
+func (r *Reader) parseMCPFile(mcpPath string, scope Scope) ([]MCPServer, error) {
+	// #nosec G304 -- path is under the plugin cache or the project root
 	data, err := os.ReadFile(mcpPath)
 	if err != nil {
 		return nil, err
@@ -138,7 +195,7 @@ func (r *Reader) parsePluginMCPFile(mcpPath string) ([]MCPServer, error) {
 			if name == "mcpServers" {
 				continue // Skip if it's wrapped
 			}
-			servers = append(servers, serverFromConfig(name, mcpPath, cfg))
+			servers = append(servers, serverFromConfig(name, scope, mcpPath, cfg))
 		}
 	}
 
@@ -146,7 +203,7 @@ func (r *Reader) parsePluginMCPFile(mcpPath string) ([]MCPServer, error) {
 	var pluginConfig PluginMCPConfig
 	if err := json.Unmarshal(data, &pluginConfig); err == nil && len(pluginConfig.MCPServers) > 0 {
 		for name, cfg := range pluginConfig.MCPServers {
-			servers = append(servers, serverFromConfig(name, mcpPath, cfg))
+			servers = append(servers, serverFromConfig(name, scope, mcpPath, cfg))
 		}
 	}
 
@@ -154,17 +211,23 @@ func (r *Reader) parsePluginMCPFile(mcpPath string) ([]MCPServer, error) {
 }
 
 func (r *Reader) readSettings() (map[string]bool, error) {
-	path := filepath.Join(r.homeDir, ".claude", "settings.json")
-	// #nosec G304 -- path is constructed from the user home directory
-	data, err := os.ReadFile(path)
+	config, err := readSettingsFile(filepath.Join(r.ClaudeDir(), "settings.json"))
 	if err != nil {
 		return nil, err
 	}
+	return config.EnabledPlugins, nil
+}
+
+func readSettingsFile(path string) (SettingsConfig, error) {
+	// #nosec G304 -- path is the user's or the project's Claude settings file
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return SettingsConfig{}, err
+	}
 
 	var config SettingsConfig
 	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, err
+		return SettingsConfig{}, err
 	}
-
-	return config.EnabledPlugins, nil
+	return config, nil
 }
@@ -0,0 +1,151 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Scope identifies where an MCP server is configured. The values match the
+// scopes of `claude mcp add --scope`.
+type Scope string
+
+// MCP server scopes.
+const (
+	// ScopeUser is the top-level mcpServers object in ~/.claude.json,
+	// available in every project.
+	ScopeUser Scope = "user"
+	// ScopeProject is the .mcp.json file checked into the repository root.
+	ScopeProject Scope = "project"
+	// ScopeLocal is projects[<cwd>].mcpServers in ~/.claude.json, private
+	// to the current project.
+	ScopeLocal Scope = "local"
+	// ScopePlugin marks servers provided by installed plugins. It is read-only.
+	ScopePlugin Scope = "plugin"
+)
+
+// WritableScopes lists the scopes a Writer can target.
+var WritableScopes = []Scope{ScopeUser, ScopeProject, ScopeLocal}
+
+// ParseScope validates a writable scope name.
+func ParseScope(s string) (Scope, error) {
+	for _, scope := range WritableScopes {
+		if strings.EqualFold(s, string(scope)) {
+			return scope, nil
+		}
+	}
+	return "", fmt.Errorf("invalid scope %q: expected user, project or local", s)
+}
+
+// FindProjectRoot returns the nearest directory at or above dir containing
+// a .git entry, or dir itself when it is not inside a repository.
+func FindProjectRoot(dir string) string {
+	if root, ok := findUp(dir, ".git", ""); ok {
+		return root
+	}
+	return dir
+}
+
+// FindProjectMCPFile returns the nearest .mcp.json at or above dir. The
+// search stops at the repository root (or the filesystem root outside a
+// repository). When no file exists, it returns the path where the project's
+// .mcp.json should be created: the repository root, or dir itself.
+func FindProjectMCPFile(dir string) string {
+	root, inRepo := findUp(dir, ".git", "")
+	if !inRepo {
+		root = dir
+	}
+
+	stop := ""
+	if inRepo {
+		stop = root
+	}
+	if found, ok := findUp(dir, ".mcp.json", stop); ok {
+		return filepath.Join(found, ".mcp.json")
+	}
+	return filepath.Join(root, ".mcp.json")
+}
+
+// findUp returns the nearest directory at or above dir that contains name,
+// not searching above stop (when non-empty).
+func findUp(dir, name, stop string) (string, bool) {
+	if dir == "" {
+		return "", false
+	}
+	for current := dir; ; {
+		if _, err := os.Stat(filepath.Join(current, name)); err == nil {
+			return current, true
+		}
+		parent := filepath.Dir(current)
+		if current == stop || parent == current {
+			return "", false
+		}
+		current = parent
+	}
+}
+
+// workingDir returns the absolute current directory, or "" if unknown.
+func workingDir() string {
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	return dir
+}
+
+// serverLocation describes where a scope's mcpServers object lives.
+type serverLocation struct {
+	path  string   // JSON file holding the servers
+	label string   // file name used in messages
+	keys  []string // object path to the mcpServers map
+	// create allows the file to be created when missing. Claude Code owns
+	// ~/.claude.json, but .mcp.json is ours to create.
+	create bool
+}
+
+// location resolves the Writer's scope to a file and key path.
+func (w *Writer) location() serverLocation {
+	switch w.scope {
+	case ScopeProject:
+		return serverLocation{
+			path:   FindProjectMCPFile(w.projectDir),
+			label:  ".mcp.json",
+			keys:   []string{"mcpServers"},
+			create: true,
+		}
+	case ScopeLocal:
+		return serverLocation{
+			path:  claudeJSONPath(w.homeDir, w.configDir),
+			label: "claude.json",
+			keys:  []string{"projects", w.projectDir, "mcpServers"},
+		}
+	default:
+		return serverLocation{
+			path:  claudeJSONPath(w.homeDir, w.configDir),
+			label: "claude.json",
+			keys:  []string{"mcpServers"},
+		}
+	}
+}
+
+// objectAt walks nested objects along keys. With create set, missing or
+// non-object levels are replaced by empty objects; otherwise nil is returned.
+func objectAt(root map[string]any, keys []string, create bool) map[string]any {
+	current := root
+	for _, key := range keys {
+		next, ok := current[key].(map[string]any)
+		if !ok {
+			if !create {
+				return nil
+			}
+			next = make(map[string]any)
+			current[key] = next
+		}
+		current = next
+	}
+	return current
+}
@@ -0,0 +1,253 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseScope(t *testing.T) {
+	for _, s := range []string{"user", "project", "local", "USER"} {
+		if _, err := ParseScope(s); err != nil {
+			t.Errorf("ParseScope(%q) error = %v", s, err)
+		}
+	}
+	for _, s := range []string{"", "plugin", "global"} {
+		if _, err := ParseScope(s); err == nil {
+			t.Errorf("ParseScope(%q) should fail", s)
+		}
+	}
+}
+
+func TestFindProjectRoot(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(filepath.Join(root, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := FindProjectRoot(sub); got != root {
+		t.Errorf("FindProjectRoot() = %s, want %s", got, root)
+	}
+
+	plain := t.TempDir()
+	if got := FindProjectRoot(plain); got != plain {
+		t.Errorf("FindProjectRoot() outside a repo = %s, want %s", got, plain)
+	}
+}
+
+// setupScopes creates a home with user and local servers and a project
+// repository with a .mcp.json.
+func setupScopes(t *testing.T) (home, project string) {
+	t.Helper()
+	home = t.TempDir()
+	project = t.TempDir()
+	if err := os.MkdirAll(filepath.Join(project, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	writeTestClaudeJSON(t, home, map[string]any{
+		"mcpServers": map[string]any{
+			"user-server": map[string]any{"command": "npx"},
+		},
+		"projects": map[string]any{
+			project: map[string]any{
+				"mcpServers": map[string]any{
+					"local-server": map[string]any{"command": "uvx"},
+				},
+			},
+			"/other/project": map[string]any{
+				"mcpServers": map[string]any{
+					"other-server": map[string]any{"command": "node"},
+				},
+			},
+		},
+	})
+
+	data, _ := json.Marshal(map[string]any{
+		"mcpServers": map[string]any{
+			"project-server": map[string]any{"type": "http", "url": "https://example.com/mcp"},
+		},
+	})
+	if err := os.WriteFile(filepath.Join(project, ".mcp.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return home, project
+}
+
+func TestReader_ListMCPServersScopes(t *testing.T) {
+	home, project := setupScopes(t)
+
+	reader := &Reader{homeDir: home, projectDir: project}
+	servers, err := reader.ListMCPServers()
+	if err != nil {
+		t.Fatalf("ListMCPServers() error = %v", err)
+	}
+
+	got := make(map[string]string)
+	for _, s := range servers {
+		got[s.Name] = s.Source
+	}
+	want := map[string]string{
+		"user-server":    "user",
+		"local-server":   "local",
+		"project-server": "project",
+	}
+	if len(got) != len(want) {
+		t.Errorf("got servers %v, want %v", got, want)
+	}
+	for name, scope := range want {
+		if got[name] != scope {
+			t.Errorf("%s scope = %q, want %q", name, got[name], scope)
+		}
+	}
+}
+
+func TestReader_ListMCPServersDisabledForProject(t *testing.T) {
+	home, project := setupScopes(t)
+
+	writeTestClaudeJSON(t, home, map[string]any{
+		"mcpServers": map[string]any{
+			"user-server": map[string]any{"command": "npx"},
+			"user-other":  map[string]any{"command": "npx"},
+		},
+		"projects": map[string]any{
+			project: map[string]any{
+				"mcpServers": map[string]any{
+					"local-server": map[string]any{"command": "uvx"},
+				},
+				"disabledMcpServers": []string{"user-server", "local-server"},
+			},
+		},
+	})
+	data, _ := json.Marshal(map[string]any{
+		"mcpServers": map[string]any{
+			"project-server": map[string]any{"type": "http", "url": "https://example.com/mcp"},
+			"project-other":  map[string]any{"type": "http", "url": "https://example.com/other"},
+			"user-other":     map[string]any{"type": "http", "url": "https://example.com/user"},
+		},
+	})
+	if err := os.WriteFile(filepath.Join(project, ".mcp.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(project, ".claude"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	settings := `{"disabledMcpjsonServers": ["project-server", "user-other"]}`
+	if err := os.WriteFile(filepath.Join(project, ".claude", "settings.local.json"), []byte(settings), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := &Reader{homeDir: home, projectDir: project}
+	servers, err := reader.ListMCPServers()
+	if err != nil {
+		t.Fatalf("ListMCPServers() error = %v", err)
+	}
+	got := make(map[string]bool)
+	for _, s := range servers {
+		got[s.Source+"/"+s.Name] = s.Enabled
+	}
+	want := map[string]bool{
+		"user/user-server":       false,
+		"user/user-other":        true,
+		"local/local-server":     false,
+		"project/project-server": false,
+		"project/project-other":  true,
+		"project/user-other":     false,
+	}
+	for key, enabled := range want {
+		if e, ok := got[key]; !ok || e != enabled {
+			t.Errorf("%s enabled = %v (listed %v), want %v", key, e, ok, enabled)
+		}
+	}
+}
+
+func TestWriter_Scopes(t *testing.T) {
+	home, project := setupScopes(t)
+	base := &Writer{homeDir: home, projectDir: project}
+
+	tests := []struct {
+		scope    Scope
+		existing string
+	}{
+		{ScopeUser, "user-server"},
+		{ScopeLocal, "local-server"},
+		{ScopeProject, "project-server"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.scope), func(t *testing.T) {
+			writer := base.WithScope(tt.scope)
+
+			servers, err := writer.ListMCPServerEntries()
+			if err != nil {
+				t.Fatalf("ListMCPServerEntries() error = %v", err)
+			}
+			if len(servers) != 1 {
+				t.Errorf("got %d servers, want only %s", len(servers), tt.existing)
+			}
+			if _, ok := servers[tt.existing]; !ok {
+				t.Errorf("%s should be listed in %s scope", tt.existing, tt.scope)
+			}
+
+			name := "new-" + string(tt.scope)
+			if err := writer.AddMCPServer(name, MCPServerEntry{Command: "npx"}); err != nil {
+				t.Fatalf("AddMCPServer() error = %v", err)
+			}
+			if exists, _ := writer.MCPServerExists(name); !exists {
+				t.Errorf("%s should exist after add", name)
+			}
+			if err := writer.RemoveMCPServer(tt.existing); err != nil {
+				t.Fatalf("RemoveMCPServer() error = %v", err)
+			}
+		})
+	}
+
+	// Other projects' local servers are untouched.
+	data, _ := os.ReadFile(filepath.Join(home, ".claude.json"))
+	var config ClaudeConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := config.Projects["/other/project"].MCPServers["other-server"]; !ok {
+		t.Error("other project's servers should be preserved")
+	}
+}
+
+func TestWriter_ProjectScopeCreatesFile(t *testing.T) {
+	home := t.TempDir()
+	project := t.TempDir()
+	writer := (&Writer{homeDir: home, projectDir: project}).WithScope(ScopeProject)
+
+	if err := writer.AddMCPServer("new", MCPServerEntry{Command: "npx"}); err != nil {
+		t.Fatalf("AddMCPServer() error = %v", err)
+	}
+
+	if writer.ServersPath() != filepath.Join(project, ".mcp.json") {
+		t.Errorf("ServersPath() = %s", writer.ServersPath())
+	}
+	if _, err := os.Stat(writer.ServersPath()); err != nil {
+		t.Errorf(".mcp.json should be created: %v", err)
+	}
+}
+
+func TestWriter_ListMCPServersGlobal(t *testing.T) {
+	home, project := setupScopes(t)
+
+	// The deprecated call always reads the user scope.
+	writer := (&Writer{homeDir: home, projectDir: project}).WithScope(ScopeLocal)
+	servers, err := writer.ListMCPServersGlobal()
+	if err != nil {
+		t.Fatalf("ListMCPServersGlobal() error = %v", err)
+	}
+	if _, ok := servers["user-server"]; !ok || len(servers) != 1 {
+		t.Errorf("ListMCPServersGlobal() = %v, want only user-server", servers)
+	}
+}
@@ -7,6 +7,7 @@ package config
 // MCP server type wire values (Claude Code / MCP protocol).
 const (
 	TypeHTTP    = "http"
+	TypeSSE     = "sse"
 	TypeCommand = "command"
 	TypeStdio   = "stdio"
 )
@@ -14,23 +15,31 @@ const (
 // MCPServer represents an MCP server configuration.
 type MCPServer struct {
 	Name    string            `json:"name"`
-	Type    string            `json:"type"`    // "http" or "command"
-	URL     string            `json:"url"`     // For HTTP type
-	Command string            `json:"command"` // For command type (npx, uvx)
-	Args    []string          `json:"args"`    // Command arguments
-	Headers map[string]string `json:"headers"` // HTTP headers
-	Enabled bool              `json:"enabled"`
-	Source  string            `json:"source"` // Config file source
+	Type    string            `json:"type"`             // "http" or "command"
+	URL     string            `json:"url"`              // For HTTP type
+	Command string            `json:"command"`          // For command type (npx, uvx)
+	Args    []string          `json:"args"`             // Command arguments
+	Headers map[string]string `json:"headers"`          // HTTP headers
+	Env     map[string]string `json:"env"`              // Environment for command servers
+	Enabled bool              `json:"enabled"`          // Plugin servers follow their plugin; others unless disabled for the project
+	Source  string            `json:"source"`           // Scope the server comes from (user, project, local, plugin)
+	Path    string            `json:"path"`             // Config file the server is defined in
+	Plugin  string            `json:"plugin,omitempty"` // Owning plugin ID for plugin-scope servers
 }
 
 // ClaudeConfig represents the ~/.claude.json structure.
 type ClaudeConfig struct {
-	Projects map[string]ProjectConfig `json:"projects"`
+	MCPServers map[string]MCPServerConfig `json:"mcpServers"` //nolint:tagliatelle // external protocol wire format
+	Projects   map[string]ProjectConfig   `json:"projects"`
 }
 
 // ProjectConfig represents per-project configuration.
 type ProjectConfig struct {
 	MCPServers map[string]MCPServerConfig `json:"mcpServers"` //nolint:tagliatelle // external protocol wire format
+	// Servers turned off for the project, e.g. with /mcp in Claude Code:
+	// user and local servers, and .mcp.json servers.
+	DisabledMCPServers     []string `json:"disabledMcpServers"`     //nolint:tagliatelle // external protocol wire format
+	DisabledMCPJSONServers []string `json:"disabledMcpjsonServers"` //nolint:tagliatelle // external protocol wire format
 }
 
 // MCPServerConfig represents the raw MCP server config from JSON.
@@ -40,6 +49,7 @@ type MCPServerConfig struct {
 	Command string            `json:"command"`
 	Args    []string          `json:"args"`
 	Headers map[string]string `json:"headers"`
+	Env     map[string]string `json:"env"`
 }
 
 // PluginMCPConfig represents .mcp.json structure.
@@ -47,9 +57,11 @@ type PluginMCPConfig struct {
 	MCPServers map[string]MCPServerConfig `json:"mcpServers"` //nolint:tagliatelle // external protocol wire format
 }
 
-// SettingsConfig represents ~/.claude/settings.json structure.
+// SettingsConfig represents ~/.claude/settings.json structure, and that of
+// a project's .claude/settings.json and .claude/settings.local.json.
 type SettingsConfig struct {
-	EnabledPlugins map[string]bool `json:"enabledPlugins"` //nolint:tagliatelle // external protocol wire format
+	EnabledPlugins         map[string]bool `json:"enabledPlugins"`         //nolint:tagliatelle // external protocol wire format
+	DisabledMCPJSONServers []string        `json:"disabledMcpjsonServers"` //nolint:tagliatelle // external protocol wire format
 }
 
 // resolveServerType picks the configured type or infers from fields.
@@ -65,7 +77,7 @@ func resolveServerType(cfg MCPServerConfig) string {
 }
 
 // serverFromConfig builds an MCPServer from raw config fields.
-func serverFromConfig(name, source string, cfg MCPServerConfig) MCPServer {
+func serverFromConfig(name string, scope Scope, path string, cfg MCPServerConfig) MCPServer {
 	return MCPServer{
 		Name:    name,
 		Type:    resolveServerType(cfg),
@@ -73,6 +85,8 @@ func serverFromConfig(name, source string, cfg MCPServerConfig) MCPServer {
 		Command: cfg.Command,
 		Args:    cfg.Args,
 		Headers: cfg.Headers,
-		Source:  source,
+		Env:     cfg.Env,
+		Source:  string(scope),
+		Path:    path,
 	}
 }
@@ -5,7 +5,9 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 )
@@ -14,35 +16,62 @@ import (
 const filePerm = 0o600
 
 // Writer writes Claude Code MCP configurations.
+//
+// MCP server methods operate on the Writer's scope (ScopeUser unless changed
+// with WithScope). Plugin methods always use ~/.claude/settings.json.
 type Writer struct {
-	homeDir string
+	homeDir    string
+	configDir  string // Overrides ~/.claude when set (CLAUDE_CONFIG_DIR)
+	projectDir string
+	scope      Scope
+
+	// MaxBackups caps the number of retained snapshots (DefaultMaxBackups when zero).
+	MaxBackups int
 }
 
 // NewWriter creates a new configuration writer.
-func NewWriter() *Writer {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		home = ""
+func NewWriter(opts ...Option) *Writer {
+	home, configDir := resolveDirs(opts)
+	return &Writer{homeDir: home, configDir: configDir, projectDir: workingDir(), scope: ScopeUser}
+}
+
+// ClaudeDir returns Claude Code's configuration directory.
+func (w *Writer) ClaudeDir() string {
+	return claudeDir(w.homeDir, w.configDir)
+}
+
+// WithScope returns a copy of the Writer targeting the given scope.
+func (w *Writer) WithScope(scope Scope) *Writer {
+	scoped := *w
+	scoped.scope = scope
+	return &scoped
+}
+
+// Scope returns the scope MCP server methods operate on.
+func (w *Writer) Scope() Scope {
+	if w.scope == "" {
+		return ScopeUser
 	}
-	return &Writer{homeDir: home}
+	return w.scope
+}
+
+// ServersPath returns the file holding the MCP servers of the Writer's scope.
+func (w *Writer) ServersPath() string {
+	return w.location().path
 }
 
 // SetPluginEnabled enables or disables a plugin in settings.json.
 func (w *Writer) SetPluginEnabled(pluginID string, enabled bool) error {
-	path := filepath.Join(w.homeDir, ".claude", "settings.json")
-
-	// #nosec G304 -- path is constructed from the user home directory
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return fmt.Errorf("failed to read settings: %w", err)
-	}
+	path := filepath.Join(w.ClaudeDir(), "settings.json")
 
-	// Parse as generic map to preserve all fields
-	var settings map[string]any
-	if err := json.Unmarshal(data, &settings); err != nil {
-		return fmt.Errorf("failed to parse settings: %w", err)
-	}
+	return w.modifyJSON(path, "settings", func(settings map[string]any) error {
+		setPlugin(settings, pluginID, enabled)
+		return nil
+	})
+}
 
+// setPlugin records a plugin's state in the settings object.
+func setPlugin(settings map[string]any, pluginID string, enabled bool) {
 	// Get or create enabledPlugins map
 	enabledPlugins, ok := settings["enabledPlugins"].(map[string]any)
 	if !ok {
@@ -52,23 +81,11 @@ func (w *Writer) SetPluginEnabled(pluginID string, enabled bool) error {
 	// Update the plugin state
 	enabledPlugins[pluginID] = enabled
 	settings["enabledPlugins"] = enabledPlugins
-
-	// Write back with pretty formatting
-	output, err := json.MarshalIndent(settings, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal settings: %w", err)
-	}
-
-	if err := os.WriteFile(path, output, filePerm); err != nil {
-		return fmt.Errorf("failed to write settings: %w", err)
-	}
-
-	return nil
 }
 
 // ListPlugins returns the list of all known plugins with their enabled status.
 func (w *Writer) ListPlugins() (map[string]bool, error) {
-	path := filepath.Join(w.homeDir, ".claude", "settings.json")
+	path := filepath.Join(w.ClaudeDir(), "settings.json")
 
 	// #nosec G304 -- path is constructed from the user home directory
 	data, err := os.ReadFile(path)
@@ -116,175 +133,116 @@ func (w *Writer) GetPluginStatus(pluginID string) (enabled, exists bool, err err
 	return status, exists, nil
 }
 
-// MCPServerEntry represents an MCP server configuration for installation.
-type MCPServerEntry struct {
-	Type    string            `json:"type,omitempty"`
-	Command string            `json:"command,omitempty"`
-	Args    []string          `json:"args,omitempty"`
-	URL     string            `json:"url,omitempty"`
-	Headers map[string]string `json:"headers,omitempty"`
-	Enabled bool              `json:"enabled,omitempty"`
-}
-
-// AddMCPServer adds a new MCP server to claude.json.
+// AddMCPServer adds a new MCP server to the Writer's scope.
 func (w *Writer) AddMCPServer(name string, entry MCPServerEntry) error {
-	path := filepath.Join(w.homeDir, ".claude.json")
-
-	// #nosec G304 -- path is constructed from the user home directory
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return fmt.Errorf("failed to read claude.json: %w", err)
-	}
-
-	var config map[string]any
-	if err := json.Unmarshal(data, &config); err != nil {
-		return fmt.Errorf("failed to parse claude.json: %w", err)
-	}
-
-	// Get or create mcpServers map
-	mcpServers, ok := config["mcpServers"].(map[string]any)
-	if !ok {
-		mcpServers = make(map[string]any)
-	}
+	return w.modifyServers(func(mcpServers map[string]any) error {
+		return w.addServer(mcpServers, name, entry)
+	})
+}
 
+func (w *Writer) addServer(mcpServers map[string]any, name string, entry MCPServerEntry) error {
 	// Check if server already exists
 	if _, exists := mcpServers[name]; exists {
-		return fmt.Errorf("MCP server '%s' already exists", name)
-	}
-
-	// Add the new server
-	serverConfig := make(map[string]any)
-	if entry.Type != "" {
-		serverConfig["type"] = entry.Type
-	}
-	if entry.Command != "" {
-		serverConfig["command"] = entry.Command
-	}
-	if len(entry.Args) > 0 {
-		serverConfig["args"] = entry.Args
-	}
-	if entry.URL != "" {
-		serverConfig["url"] = entry.URL
-	}
-	if len(entry.Headers) > 0 {
-		serverConfig["headers"] = entry.Headers
-	}
-	if entry.Enabled {
-		serverConfig["enabled"] = entry.Enabled
+		return fmt.Errorf("MCP server '%s' already exists in %s scope", name, w.Scope())
 	}
 
-	mcpServers[name] = serverConfig
-	config["mcpServers"] = mcpServers
+	mcpServers[name] = entry.toMap()
+	return nil
+}
 
-	// Write back
-	output, err := json.MarshalIndent(config, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal claude.json: %w", err)
-	}
+// RemoveMCPServer removes an MCP server from the Writer's scope.
+func (w *Writer) RemoveMCPServer(name string) error {
+	return w.modifyServers(func(mcpServers map[string]any) error {
+		return w.removeServer(mcpServers, name)
+	})
+}
 
-	if err := os.WriteFile(path, output, filePerm); err != nil {
-		return fmt.Errorf("failed to write claude.json: %w", err)
+func (w *Writer) removeServer(mcpServers map[string]any, name string) error {
+	if _, exists := mcpServers[name]; !exists {
+		return fmt.Errorf("MCP server '%s' not found in %s scope", name, w.Scope())
 	}
 
+	delete(mcpServers, name)
 	return nil
 }
 
-// RemoveMCPServer removes an MCP server from claude.json.
-func (w *Writer) RemoveMCPServer(name string) error {
-	path := filepath.Join(w.homeDir, ".claude.json")
+// UpdateMCPServer patches an existing MCP server in place.
+// The modeled fields of entry replace the stored ones; fields this tool does
+// not model are kept, with entry.Extra merged over them.
+func (w *Writer) UpdateMCPServer(name string, entry MCPServerEntry) error {
+	return w.modifyServers(func(mcpServers map[string]any) error {
+		return w.updateServer(mcpServers, name, entry)
+	})
+}
 
-	// #nosec G304 -- path is constructed from the user home directory
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return fmt.Errorf("failed to read claude.json: %w", err)
+func (w *Writer) updateServer(mcpServers map[string]any, name string, entry MCPServerEntry) error {
+	existing, exists := mcpServers[name].(map[string]any)
+	if !exists {
+		return fmt.Errorf("MCP server '%s' not found in %s scope", name, w.Scope())
 	}
 
-	var config map[string]any
-	if err := json.Unmarshal(data, &config); err != nil {
-		return fmt.Errorf("failed to parse claude.json: %w", err)
-	}
+	entry.Extra = mergeExtra(entryFromMap(existing).Extra, entry.Extra)
+	mcpServers[name] = entry.toMap()
+	return nil
+}
 
-	mcpServers, ok := config["mcpServers"].(map[string]any)
-	if !ok {
-		return fmt.Errorf("MCP server '%s' not found", name)
-	}
+// modifyServers runs mutate on the scope's mcpServers object, creating the
+// object when it does not exist yet.
+func (w *Writer) modifyServers(mutate func(mcpServers map[string]any) error) error {
+	loc := w.location()
+	return w.modifyJSONFile(loc.path, loc.label, loc.create, func(config map[string]any) error {
+		return mutate(objectAt(config, loc.keys, true))
+	})
+}
 
-	if _, exists := mcpServers[name]; !exists {
-		return fmt.Errorf("MCP server '%s' not found", name)
-	}
+// ListMCPServerEntries returns the MCP servers configured in the Writer's scope.
+func (w *Writer) ListMCPServerEntries() (map[string]MCPServerEntry, error) {
+	loc := w.location()
 
-	delete(mcpServers, name)
-	config["mcpServers"] = mcpServers
-
-	output, err := json.MarshalIndent(config, "", "  ")
+	// #nosec G304 -- path is the Claude config or project .mcp.json for the scope
+	data, err := os.ReadFile(loc.path)
+	if errors.Is(err, fs.ErrNotExist) && loc.create {
+		return make(map[string]MCPServerEntry), nil
+	}
 	if err != nil {
-		return fmt.Errorf("failed to marshal claude.json: %w", err)
+		return nil, fmt.Errorf("failed to read %s: %w", loc.label, err)
 	}
 
-	if err := os.WriteFile(path, output, filePerm); err != nil {
-		return fmt.Errorf("failed to write claude.json: %w", err)
+	servers, err := serversAt(data, loc.keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", loc.label, err)
 	}
-
-	return nil
+	return servers, nil
 }
 
-// ListMCPServersGlobal returns global MCP servers from claude.json.
+// ListMCPServersGlobal returns the user-scope MCP servers from claude.json.
+//
+// Deprecated: Use WithScope(ScopeUser).ListMCPServerEntries.
 func (w *Writer) ListMCPServersGlobal() (map[string]MCPServerEntry, error) {
-	path := filepath.Join(w.homeDir, ".claude.json")
-
-	// #nosec G304 -- path is constructed from the user home directory
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read claude.json: %w", err)
-	}
+	return w.WithScope(ScopeUser).ListMCPServerEntries()
+}
 
+// serversAt decodes the server objects found at keys in a JSON document.
+func serversAt(data []byte, keys []string) (map[string]MCPServerEntry, error) {
 	var config map[string]any
 	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("failed to parse claude.json: %w", err)
+		return nil, err
 	}
 
 	result := make(map[string]MCPServerEntry)
-	mcpServers, ok := config["mcpServers"].(map[string]any)
-	if !ok {
-		return result, nil
-	}
-
-	for name, v := range mcpServers {
+	for name, v := range objectAt(config, keys, false) {
 		cfg, ok := v.(map[string]any)
 		if !ok {
 			continue
 		}
-
-		entry := MCPServerEntry{}
-		if t, ok := cfg["type"].(string); ok {
-			entry.Type = t
-		}
-		if cmd, ok := cfg["command"].(string); ok {
-			entry.Command = cmd
-		}
-		if args, ok := cfg["args"].([]any); ok {
-			for _, arg := range args {
-				if s, ok := arg.(string); ok {
-					entry.Args = append(entry.Args, s)
-				}
-			}
-		}
-		if url, ok := cfg["url"].(string); ok {
-			entry.URL = url
-		}
-		if enabled, ok := cfg["enabled"].(bool); ok {
-			entry.Enabled = enabled
-		}
-
-		result[name] = entry
+		result[name] = entryFromMap(cfg)
 	}
-
 	return result, nil
 }
 
-// MCPServerExists checks if an MCP server exists in claude.json.
+// MCPServerExists checks if an MCP server exists in the Writer's scope.
 func (w *Writer) MCPServerExists(name string) (bool, error) {
-	servers, err := w.ListMCPServersGlobal()
+	servers, err := w.ListMCPServerEntries()
 	if err != nil {
 		return false, err
 	}
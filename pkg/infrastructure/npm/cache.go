@@ -0,0 +1,73 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package npm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gizzahub/gzh-cli-mcp-plugin/internal/fsutil"
+)
+
+// ErrNotCached is returned in offline mode for responses that were never
+// fetched.
+var ErrNotCached = errors.New("not in the local cache (offline)")
+
+// responseCache keeps registry responses on disk, one file per request. A
+// response younger than ttl is used as is; an older one is revalidated with
+// its ETag.
+type responseCache struct {
+	dir string
+	ttl time.Duration
+}
+
+type cacheEntry struct {
+	URL       string          `json:"url"`
+	ETag      string          `json:"etag,omitempty"`
+	FetchedAt time.Time       `json:"fetched_at"`
+	Body      json.RawMessage `json:"body"`
+}
+
+// cacheKey names the file of a request. The Accept header is part of it:
+// full and abbreviated packuments are different documents.
+func cacheKey(reqURL, accept string) string {
+	sum := sha256.Sum256([]byte(accept + "\n" + reqURL))
+	return hex.EncodeToString(sum[:16]) + ".json"
+}
+
+func (c *responseCache) fresh(entry *cacheEntry) bool {
+	return time.Since(entry.FetchedAt) < c.ttl
+}
+
+// load returns the cached response for key, or nil.
+func (c *responseCache) load(key string) *cacheEntry {
+	// #nosec G304 -- key is a hex digest inside the cache directory
+	data, err := os.ReadFile(filepath.Join(c.dir, key))
+	if err != nil {
+		return nil
+	}
+	var entry cacheEntry
+	if json.Unmarshal(data, &entry) != nil || len(entry.Body) == 0 {
+		return nil
+	}
+	return &entry
+}
+
+// store writes entry atomically. The cache only saves requests, so failures
+// are ignored.
+func (c *responseCache) store(key string, entry *cacheEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return
+	}
+	_ = fsutil.WriteFile(filepath.Join(c.dir, key), data, 0o600)
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package npm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// etagRegistry serves one package with an ETag and counts full responses
+// and 304s separately.
+type etagRegistry struct {
+	*httptest.Server
+	full, notModified atomic.Int32
+	lastAccept        atomic.Value
+}
+
+func newETagRegistry(t *testing.T) *etagRegistry {
+	t.Helper()
+	reg := &etagRegistry{}
+	reg.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reg.lastAccept.Store(r.Header.Get("Accept"))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			reg.notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		reg.full.Add(1)
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{"name":"demo-mcp","dist-tags":{"latest":"1.2.0"}}`))
+	}))
+	t.Cleanup(reg.Close)
+	return reg
+}
+
+func TestClient_Cache(t *testing.T) {
+	ctx := context.Background()
+	reg := newETagRegistry(t)
+	dir := t.TempDir()
+
+	fresh := NewClient(WithBaseURL(reg.URL), WithCache(dir, time.Hour))
+	for range 2 {
+		if _, err := fresh.GetPackage(ctx, "demo-mcp"); err != nil {
+			t.Fatalf("GetPackage() error = %v", err)
+		}
+	}
+	if reg.full.Load() != 1 || reg.notModified.Load() != 0 {
+		t.Errorf("within TTL: %d full, %d not modified; want 1, 0", reg.full.Load(), reg.notModified.Load())
+	}
+
+	stale := NewClient(WithBaseURL(reg.URL), WithCache(dir, 0))
+	pkg, err := stale.GetPackage(ctx, "demo-mcp")
+	if err != nil {
+		t.Fatalf("GetPackage() error = %v", err)
+	}
+	if pkg.LatestVersion() != "1.2.0" {
+		t.Errorf("LatestVersion() = %q after 304, want 1.2.0", pkg.LatestVersion())
+	}
+	if reg.full.Load() != 1 || reg.notModified.Load() != 1 {
+		t.Errorf("after TTL: %d full, %d not modified; want 1, 1", reg.full.Load(), reg.notModified.Load())
+	}
+}
+
+func TestClient_Offline(t *testing.T) {
+	ctx := context.Background()
+	reg := newETagRegistry(t)
+	dir := t.TempDir()
+
+	if _, err := NewClient(WithBaseURL(reg.URL), WithCache(dir, 0)).GetPackage(ctx, "demo-mcp"); err != nil {
+		t.Fatalf("GetPackage() error = %v", err)
+	}
+	reg.Close()
+
+	offline := NewClient(WithBaseURL(reg.URL), WithCache(dir, 0), WithOffline(true))
+	if _, err := offline.GetPackage(ctx, "demo-mcp"); err != nil {
+		t.Errorf("offline GetPackage() error = %v, want the stale cached response", err)
+	}
+	if _, err := offline.GetPackageVersions(ctx, "demo-mcp"); !errors.Is(err, ErrNotCached) {
+		t.Errorf("offline GetPackageVersions() error = %v, want ErrNotCached", err)
+	}
+}
+
+func TestClient_GetPackageVersions(t *testing.T) {
+	reg := newETagRegistry(t)
+	if _, err := NewClient(WithBaseURL(reg.URL)).GetPackageVersions(context.Background(), "demo-mcp"); err != nil {
+		t.Fatalf("GetPackageVersions() error = %v", err)
+	}
+	if accept, _ := reg.lastAccept.Load().(string); !strings.HasPrefix(accept, "application/vnd.npm.install-v1+json") {
+		t.Errorf("Accept = %q, want the abbreviated document", accept)
+	}
+}
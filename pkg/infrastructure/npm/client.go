@@ -0,0 +1,207 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package npm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultRegistry is the public npm registry.
+const DefaultRegistry = "https://registry.npmjs.org"
+
+const defaultTimeout = 30 * time.Second
+
+// Accept headers for package documents. The abbreviated ("corgi") document
+// carries only what installs need: dist-tags and per-version manifests.
+const (
+	acceptJSON        = "application/json"
+	acceptAbbreviated = "application/vnd.npm.install-v1+json; q=1.0, application/json; q=0.8"
+)
+
+// ErrNotFound is returned for packages the registry does not have.
+var ErrNotFound = errors.New("not found")
+
+// Client is an npm API client.
+type Client struct {
+	httpClient *http.Client
+	registry   string
+	proxy      string
+	auth       *credentials // explicit credentials for registry
+	npmrc      *Npmrc
+	cache      *responseCache
+	offline    bool
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithBaseURL sets the default registry, overriding the .npmrc one. Scoped
+// registries from .npmrc still apply to their scopes.
+func WithBaseURL(registry string) Option {
+	return func(c *Client) { c.registry = registry }
+}
+
+// WithToken sends token as a bearer token to the default registry.
+func WithToken(token string) Option {
+	return func(c *Client) { c.auth = &credentials{token: token} }
+}
+
+// WithBasicAuth sends username and password to the default registry.
+func WithBasicAuth(username, password string) Option {
+	return func(c *Client) { c.auth = &credentials{username: username, password: password} }
+}
+
+// WithTimeout bounds every request, on top of the caller's context.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) { c.httpClient.Timeout = d }
+}
+
+// WithProxy routes requests through proxyURL instead of the proxy named by
+// HTTPS_PROXY or .npmrc.
+func WithProxy(proxyURL string) Option {
+	return func(c *Client) { c.proxy = proxyURL }
+}
+
+// WithNpmrc takes the registries, credentials and proxy of rc.
+func WithNpmrc(rc *Npmrc) Option {
+	return func(c *Client) { c.npmrc = rc }
+}
+
+// WithCache keeps responses in dir. For ttl after a fetch they are used
+// without asking the registry; after that they are revalidated with
+// If-None-Match.
+func WithCache(dir string, ttl time.Duration) Option {
+	return func(c *Client) { c.cache = &responseCache{dir: dir, ttl: ttl} }
+}
+
+// WithOffline serves every request from the cache, however old, and fails
+// with ErrNotCached for anything else.
+func WithOffline(offline bool) Option {
+	return func(c *Client) { c.offline = offline }
+}
+
+// NewClient creates a new npm client. Without options it talks to the public
+// registry with a 30 second timeout.
+func NewClient(opts ...Option) *Client {
+	c := &Client{httpClient: &http.Client{Timeout: defaultTimeout}}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.npmrc == nil {
+		c.npmrc = &Npmrc{}
+	}
+	if c.registry == "" {
+		c.registry = c.npmrc.Registry
+	}
+	if c.registry == "" {
+		c.registry = DefaultRegistry
+	}
+	c.registry = strings.TrimRight(c.registry, "/")
+
+	if c.proxy == "" {
+		c.proxy = c.npmrc.proxy()
+	}
+	if c.proxy != "" && c.httpClient.Transport == nil {
+		if proxyURL, err := url.Parse(c.proxy); err == nil {
+			transport := http.DefaultTransport.(*http.Transport).Clone()
+			transport.Proxy = http.ProxyURL(proxyURL)
+			c.httpClient.Transport = transport
+		}
+	}
+	return c
+}
+
+// Registry returns the registry that serves packageName: the scope's
+// registry for scoped packages that have one, the default otherwise.
+func (c *Client) Registry(packageName string) string {
+	if scope, _, ok := strings.Cut(packageName, "/"); ok && strings.HasPrefix(scope, "@") {
+		if registry, ok := c.npmrc.Scopes[scope]; ok {
+			return strings.TrimRight(registry, "/")
+		}
+	}
+	return c.registry
+}
+
+// credentialsFor returns what to authenticate to registry with, if anything.
+// Explicit credentials are only ever sent to the default registry.
+func (c *Client) credentialsFor(registry string) *credentials {
+	if c.auth != nil && registry == c.registry {
+		return c.auth
+	}
+	return c.npmrc.credentialsFor(registry)
+}
+
+// getJSON fetches reqURL from registry into v, going through the cache
+// when there is one.
+func (c *Client) getJSON(ctx context.Context, registry, reqURL, accept string, v any) error {
+	key := cacheKey(reqURL, accept)
+	var cached *cacheEntry
+	if c.cache != nil {
+		cached = c.cache.load(key)
+	}
+	if cached != nil && (c.offline || c.cache.fresh(cached)) {
+		return decode(cached.Body, v)
+	}
+	if c.offline {
+		return ErrNotCached
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, http.NoBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", accept)
+	if cached != nil && cached.ETag != "" {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+	if cred := c.credentialsFor(registry); cred != nil {
+		cred.apply(req)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached != nil:
+		cached.FetchedAt = time.Now()
+		c.cache.store(key, cached)
+		return decode(cached.Body, v)
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("registry %s refused access (status %d); check the auth token in .npmrc", registry, resp.StatusCode)
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("status %d from %s", resp.StatusCode, registry)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+	if err := decode(body, v); err != nil {
+		return err
+	}
+	if c.cache != nil {
+		c.cache.store(key, &cacheEntry{URL: reqURL, ETag: resp.Header.Get("ETag"), FetchedAt: time.Now(), Body: body})
+	}
+	return nil
+}
+
+func decode(body []byte, v any) error {
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("parse response: %w", err)
+	}
+	return nil
+}
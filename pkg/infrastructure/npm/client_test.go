@@ -0,0 +1,181 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package npm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// registry serves a single package and records the Authorization header of
+// the last request.
+func registry(t *testing.T, wantAuth string) (*httptest.Server, *string) {
+	t.Helper()
+	var gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		if wantAuth != "" && gotAuth != wantAuth {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.URL.Path == "/-/v1/search":
+			_, _ = w.Write([]byte(`{"objects":[{"package":{"name":"demo-mcp","version":"1.0.0"}}],"total":1}`))
+		case strings.HasSuffix(r.URL.Path, "demo-mcp"):
+			_, _ = w.Write([]byte(`{"name":"demo-mcp","dist-tags":{"latest":"1.2.0"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &gotAuth
+}
+
+func TestClient_Options(t *testing.T) {
+	ctx := context.Background()
+
+	srv, _ := registry(t, "Bearer tok")
+	client := NewClient(WithBaseURL(srv.URL+"/"), WithToken("tok"))
+	result, err := client.Search(ctx, "demo", 5)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if result.Total != 1 || result.Objects[0].Package.Name != "demo-mcp" {
+		t.Errorf("Search() = %+v", result)
+	}
+
+	basic, _ := registry(t, "Basic dXNlcjpwYXNz")
+	pkg, err := NewClient(WithBaseURL(basic.URL), WithBasicAuth("user", "pass")).GetPackage(ctx, "demo-mcp")
+	if err != nil {
+		t.Fatalf("GetPackage() error = %v", err)
+	}
+	if pkg.LatestVersion() != "1.2.0" {
+		t.Errorf("LatestVersion() = %q, want 1.2.0", pkg.LatestVersion())
+	}
+
+	if _, err := NewClient(WithBaseURL(basic.URL)).GetPackage(ctx, "demo-mcp"); err == nil || !strings.Contains(err.Error(), "refused access") {
+		t.Errorf("GetPackage() without auth error = %v, want refused access", err)
+	}
+	if _, err := NewClient(WithBaseURL(srv.URL), WithToken("tok")).GetPackage(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetPackage(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestClient_Npmrc(t *testing.T) {
+	ctx := context.Background()
+	public, publicAuth := registry(t, "")
+	corp, corpAuth := registry(t, "Bearer corp-token")
+
+	rc, err := ParseNpmrc(strings.NewReader(
+		"registry=" + public.URL + "\n" +
+			"@corp:registry=" + corp.URL + "/\n" +
+			"//" + strings.TrimPrefix(corp.URL, "http://") + "/:_authToken=corp-token\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := NewClient(WithNpmrc(rc))
+
+	if got := client.Registry("@corp/demo-mcp"); got != corp.URL {
+		t.Errorf("Registry(@corp/demo-mcp) = %q, want %q", got, corp.URL)
+	}
+	if _, err := client.GetPackage(ctx, "@corp/demo-mcp"); err != nil {
+		t.Fatalf("GetPackage(@corp/demo-mcp) error = %v", err)
+	}
+	if *corpAuth != "Bearer corp-token" {
+		t.Errorf("corp registry got Authorization %q", *corpAuth)
+	}
+
+	if _, err := client.GetPackage(ctx, "demo-mcp"); err != nil {
+		t.Fatalf("GetPackage(demo-mcp) error = %v", err)
+	}
+	if *publicAuth != "" {
+		t.Errorf("public registry got Authorization %q, want none", *publicAuth)
+	}
+}
+
+func TestClient_Context(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := NewClient(WithBaseURL(srv.URL)).Search(ctx, "demo", 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Search() error = %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Search() took %v, want it to stop with the context", elapsed)
+	}
+}
+
+func TestDist_SRI(t *testing.T) {
+	tests := []struct {
+		dist Dist
+		want string
+	}{
+		{Dist{Integrity: "sha512-abc", Shasum: "00"}, "sha512-abc"},
+		{Dist{Shasum: "a94a8fe5ccb19ba61c4c0873d391e987982fbbd3"}, "sha1-qUqP5cyxm6YcTAhz05Hph5gvu9M="},
+		{Dist{}, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.dist.SRI(); got != tt.want {
+			t.Errorf("%+v.SRI() = %q, want %q", tt.dist, got, tt.want)
+		}
+	}
+}
+
+func TestPackageDetail_Decode(t *testing.T) {
+	doc := `{
+		"name": "demo",
+		"author": "Jane Doe <jane@example.com> (https://example.com)",
+		"repository": "github:acme/demo",
+		"time": {"created": "2025-01-01T00:00:00.000Z", "1.0.0": "2025-01-02T03:04:05.000Z"},
+		"versions": {
+			"1.0.0": {
+				"version": "1.0.0",
+				"scripts": {"test": "jest", "postinstall": "node setup.js"},
+				"maintainers": [{"name": "jane", "email": "jane@example.com"}],
+				"_npmUser": {"name": "jane"},
+				"repository": {"type": "git", "url": "git+https://github.com/acme/demo.git"}
+			},
+			"1.1.0": {"version": "1.1.0", "hasInstallScript": true, "deprecated": "use 2.x"}
+		}
+	}`
+	var pkg PackageDetail
+	if err := json.Unmarshal([]byte(doc), &pkg); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if pkg.Author.Name != "Jane Doe" || pkg.Author.Email != "jane@example.com" {
+		t.Errorf("Author = %+v", pkg.Author)
+	}
+	if pkg.Repository.URL != "github:acme/demo" {
+		t.Errorf("Repository = %+v", pkg.Repository)
+	}
+
+	v1 := pkg.Versions["1.0.0"]
+	if got := v1.InstallScripts(); len(got) != 1 || got[0] != "postinstall" {
+		t.Errorf("1.0.0 InstallScripts() = %v, want [postinstall]", got)
+	}
+	if v1.Publisher == nil || v1.Publisher.Name != "jane" || v1.Repository.Type != "git" {
+		t.Errorf("1.0.0 = %+v", v1)
+	}
+	if got := pkg.Versions["1.1.0"].InstallScripts(); len(got) != 1 || got[0] != "install" {
+		t.Errorf("1.1.0 InstallScripts() = %v, want [install]", got)
+	}
+
+	if at, ok := pkg.Published("1.0.0"); !ok || at.Day() != 2 {
+		t.Errorf("Published(1.0.0) = %v, %v", at, ok)
+	}
+	if _, ok := pkg.Published("1.1.0"); ok {
+		t.Error("Published(1.1.0) ok = true, want false")
+	}
+}
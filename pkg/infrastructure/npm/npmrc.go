@@ -0,0 +1,220 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package npm
+
+import (
+	"bufio"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// UserConfigEnv relocates the user's .npmrc, as it does for npm.
+const UserConfigEnv = "NPM_CONFIG_USERCONFIG"
+
+// Npmrc is the part of npm's configuration that decides where packages come
+// from and how to authenticate there.
+type Npmrc struct {
+	// Registry is the default registry.
+	Registry string
+	// Scopes maps a scope such as "@corp" to its registry.
+	Scopes map[string]string
+	// Proxy and HTTPSProxy are npm's proxy and https-proxy settings.
+	Proxy      string
+	HTTPSProxy string
+
+	// auth is keyed by registry URL without the scheme, ending in a slash
+	// ("//npm.corp.example/api/"), the way npm writes it.
+	auth map[string]credentials
+}
+
+// credentials authenticate to one registry.
+type credentials struct {
+	token    string
+	username string
+	password string
+}
+
+func (c credentials) set() bool {
+	return c.token != "" || c.username != ""
+}
+
+func (c credentials) apply(req *http.Request) {
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+		return
+	}
+	req.SetBasicAuth(c.username, c.password)
+}
+
+// NpmrcPaths returns the .npmrc files npm would read, in order of
+// precedence from low to high: the user's, then the current directory's.
+func NpmrcPaths() []string {
+	var paths []string
+	if p := os.Getenv(UserConfigEnv); p != "" {
+		paths = append(paths, p)
+	} else if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".npmrc"))
+	}
+	if wd, err := os.Getwd(); err == nil {
+		paths = append(paths, filepath.Join(wd, ".npmrc"))
+	}
+	return paths
+}
+
+// LoadNpmrc reads and merges the given files; settings in later files win.
+// Missing files are skipped.
+func LoadNpmrc(paths ...string) (*Npmrc, error) {
+	rc := &Npmrc{}
+	for _, path := range paths {
+		// #nosec G304 -- path is a user or project .npmrc
+		f, err := os.Open(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		err = rc.parse(f)
+		_ = f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	}
+	return rc, nil
+}
+
+// ParseNpmrc parses one .npmrc file.
+func ParseNpmrc(r io.Reader) (*Npmrc, error) {
+	rc := &Npmrc{}
+	if err := rc.parse(r); err != nil {
+		return nil, err
+	}
+	return rc, nil
+}
+
+func (rc *Npmrc) parse(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = expandEnv(unquote(strings.TrimSpace(value)))
+
+		switch {
+		case key == "registry":
+			rc.Registry = value
+		case key == "proxy":
+			rc.Proxy = value
+		case key == "https-proxy":
+			rc.HTTPSProxy = value
+		case strings.HasPrefix(key, "@") && strings.HasSuffix(key, ":registry"):
+			if rc.Scopes == nil {
+				rc.Scopes = map[string]string{}
+			}
+			rc.Scopes[strings.TrimSuffix(key, ":registry")] = value
+		case strings.HasPrefix(key, "//"):
+			if err := rc.setAuth(key, value); err != nil {
+				return err
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// setAuth records a "//host/path/:field" setting.
+func (rc *Npmrc) setAuth(key, value string) error {
+	i := strings.LastIndex(key, ":")
+	if i < 0 {
+		return nil
+	}
+	prefix, field := key[:i], key[i+1:]
+	if !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+
+	if rc.auth == nil {
+		rc.auth = map[string]credentials{}
+	}
+	cred := rc.auth[prefix]
+	switch field {
+	case "_authToken":
+		cred.token = value
+	case "_auth":
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return fmt.Errorf("%s: _auth is not base64", prefix)
+		}
+		cred.username, cred.password, _ = strings.Cut(string(decoded), ":")
+	case "username":
+		cred.username = value
+	case "_password":
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return fmt.Errorf("%s: _password is not base64", prefix)
+		}
+		cred.password = string(decoded)
+	default:
+		return nil
+	}
+	rc.auth[prefix] = cred
+	return nil
+}
+
+// credentialsFor finds the credentials for registry, trying its full path
+// first and then each parent path, as npm does.
+func (rc *Npmrc) credentialsFor(registry string) *credentials {
+	u, err := url.Parse(registry)
+	if err != nil || u.Host == "" {
+		return nil
+	}
+	path := strings.TrimSuffix(u.Path, "/")
+	for {
+		if cred, ok := rc.auth["//"+u.Host+path+"/"]; ok && cred.set() {
+			return &cred
+		}
+		if path == "" {
+			return nil
+		}
+		path = path[:strings.LastIndex(path, "/")]
+	}
+}
+
+// proxy returns the proxy for registry traffic, which is HTTPS in practice.
+func (rc *Npmrc) proxy() string {
+	if rc.HTTPSProxy != "" {
+		return rc.HTTPSProxy
+	}
+	return rc.Proxy
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+var envRef = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// expandEnv replaces ${VAR} with its value, the only form npm expands.
+func expandEnv(value string) string {
+	return envRef.ReplaceAllStringFunc(value, func(ref string) string {
+		return os.Getenv(ref[2 : len(ref)-1])
+	})
+}
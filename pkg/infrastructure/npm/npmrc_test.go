@@ -0,0 +1,96 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package npm
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseNpmrc(t *testing.T) {
+	t.Setenv("NPMRC_TEST_TOKEN", "from-env")
+
+	rc, err := ParseNpmrc(strings.NewReader(`
+; comment
+# another comment
+registry = https://npm.corp.example/api/npm/
+@corp:registry=https://npm.corp.example/api/corp/
+@other:registry="https://other.example/"
+https-proxy=http://proxy.corp.example:3128
+//npm.corp.example/api/:_authToken=${NPMRC_TEST_TOKEN}
+//other.example/:_auth=dXNlcjpwYXNz
+//localhost:4873/:username=alice
+//localhost:4873/:_password=czNjcjN0
+always-auth=true
+`))
+	if err != nil {
+		t.Fatalf("ParseNpmrc() error = %v", err)
+	}
+
+	if rc.Registry != "https://npm.corp.example/api/npm/" {
+		t.Errorf("Registry = %q", rc.Registry)
+	}
+	if got := rc.Scopes["@corp"]; got != "https://npm.corp.example/api/corp/" {
+		t.Errorf("Scopes[@corp] = %q", got)
+	}
+	if got := rc.Scopes["@other"]; got != "https://other.example/" {
+		t.Errorf("Scopes[@other] = %q, want quotes removed", got)
+	}
+	if got := rc.proxy(); got != "http://proxy.corp.example:3128" {
+		t.Errorf("proxy() = %q", got)
+	}
+
+	tests := []struct {
+		registry string
+		want     *credentials
+	}{
+		// Parent paths match, as in npm.
+		{"https://npm.corp.example/api/npm", &credentials{token: "from-env"}},
+		{"https://npm.corp.example/api/corp/", &credentials{token: "from-env"}},
+		{"https://npm.corp.example/", nil},
+		{"https://other.example", &credentials{username: "user", password: "pass"}},
+		{"http://localhost:4873/", &credentials{username: "alice", password: "s3cr3t"}},
+		{"https://registry.npmjs.org", nil},
+	}
+	for _, tt := range tests {
+		got := rc.credentialsFor(tt.registry)
+		if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+			t.Errorf("credentialsFor(%q) = %+v, want %+v", tt.registry, got, tt.want)
+		}
+	}
+}
+
+func TestParseNpmrc_BadAuth(t *testing.T) {
+	if _, err := ParseNpmrc(strings.NewReader("//host/:_auth=not base64!\n")); err == nil {
+		t.Error("ParseNpmrc() error = nil, want invalid _auth")
+	}
+}
+
+func TestLoadNpmrc(t *testing.T) {
+	dir := t.TempDir()
+	user := filepath.Join(dir, "user.npmrc")
+	project := filepath.Join(dir, "project.npmrc")
+	writeFile(t, user, "registry=https://user.example/\n@corp:registry=https://corp.example/\n")
+	writeFile(t, project, "registry=https://project.example/\n")
+
+	rc, err := LoadNpmrc(user, project, filepath.Join(dir, "missing"))
+	if err != nil {
+		t.Fatalf("LoadNpmrc() error = %v", err)
+	}
+	if rc.Registry != "https://project.example/" {
+		t.Errorf("Registry = %q, want the project's", rc.Registry)
+	}
+	if rc.Scopes["@corp"] != "https://corp.example/" {
+		t.Errorf("Scopes = %v, want the user's @corp kept", rc.Scopes)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
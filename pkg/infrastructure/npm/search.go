@@ -1,13 +1,17 @@
-// Package npm provides infrastructure for searching npm packages.
+// Package npm provides infrastructure for searching npm packages. Registries
+// and credentials can be taken from the user's .npmrc.
 package npm
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"net/http"
 	"net/url"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -41,6 +45,23 @@ type Author struct {
 	Username string `json:"username,omitempty"`
 }
 
+// UnmarshalJSON also accepts the "Name <email> (url)" string form that
+// package.json allows.
+func (a *Author) UnmarshalJSON(data []byte) error {
+	var s string
+	if json.Unmarshal(data, &s) == nil {
+		name, rest, _ := strings.Cut(s, "<")
+		email, _, _ := strings.Cut(rest, ">")
+		if i := strings.Index(name, "("); i >= 0 {
+			name = name[:i]
+		}
+		*a = Author{Name: strings.TrimSpace(name), Email: strings.TrimSpace(email)}
+		return nil
+	}
+	type plain Author
+	return json.Unmarshal(data, (*plain)(a))
+}
+
 // Links represents package links.
 type Links struct {
 	NPM        string `json:"npm"`
@@ -61,109 +82,150 @@ type Detail struct {
 	Maintenance float64 `json:"maintenance"`
 }
 
-// Client is an npm API client.
-type Client struct {
-	httpClient *http.Client
-	baseURL    string
-}
-
-// NewClient creates a new npm client.
-func NewClient() *Client {
-	return &Client{
-		httpClient: &http.Client{Timeout: 30 * time.Second},
-		baseURL:    "https://registry.npmjs.org",
-	}
-}
-
 // Search searches npm for MCP-related packages.
-func (c *Client) Search(query string, limit int) (*SearchResult, error) {
+func (c *Client) Search(ctx context.Context, query string, limit int) (*SearchResult, error) {
 	// Search for MCP packages
 	searchQuery := fmt.Sprintf("%s mcp", query)
 	searchURL := fmt.Sprintf("%s/-/v1/search?text=%s&size=%d",
-		c.baseURL, url.QueryEscape(searchQuery), limit)
+		c.registry, url.QueryEscape(searchQuery), limit)
 
-	// No caller context on this exported API; client timeout bounds the request.
-	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, searchURL, http.NoBody)
-	if err != nil {
-		return nil, fmt.Errorf("search npm: %w", err)
-	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
+	var result SearchResult
+	if err := c.getJSON(ctx, c.registry, searchURL, acceptJSON, &result); err != nil {
 		return nil, fmt.Errorf("search npm: %w", err)
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("npm search failed: status %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("read response: %w", err)
-	}
+	return &result, nil
+}
 
-	var result SearchResult
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("parse response: %w", err)
-	}
+// GetPackage gets detailed information about a package from the registry
+// that serves it.
+func (c *Client) GetPackage(ctx context.Context, name string) (*PackageDetail, error) {
+	return c.getPackage(ctx, name, acceptJSON)
+}
 
-	return &result, nil
+// GetPackageVersions is GetPackage for callers that only need dist-tags and
+// versions. It fetches the abbreviated document, so the readme, license and
+// links are left empty.
+func (c *Client) GetPackageVersions(ctx context.Context, name string) (*PackageDetail, error) {
+	return c.getPackage(ctx, name, acceptAbbreviated)
 }
 
-// GetPackage gets detailed information about a package.
-func (c *Client) GetPackage(name string) (*PackageDetail, error) {
-	pkgURL := fmt.Sprintf("%s/%s", c.baseURL, url.PathEscape(name))
+func (c *Client) getPackage(ctx context.Context, name, accept string) (*PackageDetail, error) {
+	registry := c.Registry(name)
+	pkgURL := fmt.Sprintf("%s/%s", registry, url.PathEscape(name))
 
-	// No caller context on this exported API; client timeout bounds the request.
-	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, pkgURL, http.NoBody)
-	if err != nil {
-		return nil, fmt.Errorf("get package: %w", err)
+	var result PackageDetail
+	err := c.getJSON(ctx, registry, pkgURL, accept, &result)
+	if errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("package '%s' %w", name, err)
 	}
-
-	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("get package: %w", err)
 	}
-	defer func() { _ = resp.Body.Close() }()
+	return &result, nil
+}
+
+// PackageDetail represents detailed package information.
+type PackageDetail struct {
+	Name        string                    `json:"name"`
+	Description string                    `json:"description"`
+	DistTags    map[string]string         `json:"dist-tags"` //nolint:tagliatelle // external protocol wire format (npm registry)
+	Versions    map[string]PackageVersion `json:"versions"`
+	// Time maps each version, plus "created" and "modified", to its
+	// RFC 3339 publish time. The abbreviated document omits it.
+	Time       map[string]string `json:"time"`
+	Readme     string            `json:"readme"`
+	Homepage   string            `json:"homepage"`
+	Repository Repository        `json:"repository"`
+	Author     *Author           `json:"author"`
+	License    string            `json:"license"`
+}
+
+// PackageVersion is the manifest of one published version. The abbreviated
+// document only carries Name, Version, Dist, Deprecated and HasInstallScript.
+type PackageVersion struct {
+	Name             string            `json:"name"`
+	Version          string            `json:"version"`
+	Dist             Dist              `json:"dist"`
+	Deprecated       string            `json:"deprecated,omitempty"`
+	HasInstallScript bool              `json:"hasInstallScript,omitempty"` //nolint:tagliatelle // external protocol wire format (npm registry)
+	Scripts          map[string]string `json:"scripts,omitempty"`
+	Repository       Repository        `json:"repository"`
+	Maintainers      []Author          `json:"maintainers,omitempty"`
+	// Publisher is the account that published the version.
+	Publisher *Author `json:"_npmUser,omitempty"` //nolint:tagliatelle // external protocol wire format (npm registry)
+}
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("package '%s' not found", name)
+// lifecycleScripts run on `npm install`, and so on every `npx -y` that
+// downloads the package.
+var lifecycleScripts = []string{"preinstall", "install", "postinstall"}
+
+// InstallScripts returns the lifecycle scripts the version runs when it is
+// installed. With only the abbreviated document, that is just "install"
+// when the registry says there are any.
+func (v PackageVersion) InstallScripts() []string {
+	var scripts []string
+	for _, name := range lifecycleScripts {
+		if v.Scripts[name] != "" {
+			scripts = append(scripts, name)
+		}
 	}
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("npm get failed: status %d", resp.StatusCode)
+	if len(scripts) == 0 && v.HasInstallScript {
+		scripts = append(scripts, "install")
 	}
+	return scripts
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("read response: %w", err)
-	}
+// Published returns when version was published, if the document says.
+func (p *PackageDetail) Published(version string) (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339, p.Time[version])
+	return t, err == nil
+}
 
-	var result PackageDetail
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("parse response: %w", err)
+// Repository is a package's source repository. Manifests may give it as an
+// object or as a bare URL string.
+type Repository struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// UnmarshalJSON accepts both forms.
+func (r *Repository) UnmarshalJSON(data []byte) error {
+	var s string
+	if json.Unmarshal(data, &s) == nil {
+		*r = Repository{URL: s}
+		return nil
 	}
+	type plain Repository
+	return json.Unmarshal(data, (*plain)(r))
+}
 
-	return &result, nil
+// Dist describes the tarball of one published version.
+type Dist struct {
+	Tarball   string `json:"tarball"`
+	Integrity string `json:"integrity,omitempty"`
+	Shasum    string `json:"shasum,omitempty"`
 }
 
-// PackageDetail represents detailed package information.
-type PackageDetail struct {
-	Name        string            `json:"name"`
-	Description string            `json:"description"`
-	DistTags    map[string]string `json:"dist-tags"` //nolint:tagliatelle // external protocol wire format (npm registry)
-	Versions    map[string]struct {
-		Name    string `json:"name"`
-		Version string `json:"version"`
-	} `json:"versions"`
-	Readme     string `json:"readme"`
-	Homepage   string `json:"homepage"`
-	Repository struct {
-		Type string `json:"type"`
-		URL  string `json:"url"`
-	} `json:"repository"`
-	Author  *Author `json:"author"`
-	License string  `json:"license"`
+// SRI returns the tarball's Subresource Integrity string. Old packages only
+// have a hex SHA-1 shasum, which is converted to the same form.
+func (d Dist) SRI() string {
+	if d.Integrity != "" {
+		return d.Integrity
+	}
+	if sum, err := hex.DecodeString(d.Shasum); err == nil && len(sum) > 0 {
+		return "sha1-" + base64.StdEncoding.EncodeToString(sum)
+	}
+	return ""
+}
+
+// VersionList returns the published versions, sorted as strings.
+func (p *PackageDetail) VersionList() []string {
+	list := make([]string, 0, len(p.Versions))
+	for v := range p.Versions {
+		list = append(list, v)
+	}
+	sort.Strings(list)
+	return list
 }
 
 // LatestVersion returns the latest version tag.
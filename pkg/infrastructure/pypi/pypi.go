@@ -0,0 +1,221 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package pypi looks up Python packages through the PyPI JSON API.
+package pypi
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the JSON API of the public index.
+const DefaultBaseURL = "https://pypi.org/pypi"
+
+// ErrNotFound is returned for projects the index does not have.
+var ErrNotFound = errors.New("not found")
+
+// Client is a PyPI JSON API client.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithBaseURL points the client at another index serving the JSON API.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) { c.baseURL = strings.TrimRight(baseURL, "/") }
+}
+
+// WithTimeout bounds every request, on top of the caller's context.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) { c.httpClient.Timeout = d }
+}
+
+// NewClient creates a client for pypi.org with a 30 second timeout.
+func NewClient(opts ...Option) *Client {
+	c := &Client{httpClient: &http.Client{Timeout: 30 * time.Second}, baseURL: DefaultBaseURL}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Project is a project's metadata and its releases.
+type Project struct {
+	Info     Info              `json:"info"`
+	Releases map[string][]File `json:"releases"`
+}
+
+// Info is the metadata of a project's latest release.
+type Info struct {
+	Name           string            `json:"name"`
+	Version        string            `json:"version"`
+	Summary        string            `json:"summary"`
+	License        string            `json:"license"`
+	Author         string            `json:"author"`
+	AuthorEmail    string            `json:"author_email"`
+	HomePage       string            `json:"home_page"`
+	RequiresPython string            `json:"requires_python"`
+	PackageURL     string            `json:"package_url"`
+	ProjectURLs    map[string]string `json:"project_urls"`
+	Yanked         bool              `json:"yanked"`
+}
+
+// File is one distribution file of a release.
+type File struct {
+	Filename     string    `json:"filename"`
+	URL          string    `json:"url"`
+	PackageType  string    `json:"packagetype"`
+	UploadTime   time.Time `json:"upload_time_iso_8601"`
+	Yanked       bool      `json:"yanked"`
+	YankedReason string    `json:"yanked_reason"`
+	Digests      struct {
+		SHA256 string `json:"sha256"`
+	} `json:"digests"`
+}
+
+// SRI returns the file's SHA-256 digest as a Subresource Integrity string,
+// the form npm uses for tarballs.
+func (f File) SRI() string {
+	sum, err := hex.DecodeString(f.Digests.SHA256)
+	if err != nil || len(sum) == 0 {
+		return ""
+	}
+	return "sha256-" + base64.StdEncoding.EncodeToString(sum)
+}
+
+// GetProject fetches a project by name. Names are normalized as in PEP 503,
+// so "MCP_Server.Git" finds "mcp-server-git".
+func (c *Client) GetProject(ctx context.Context, name string) (*Project, error) {
+	projectURL := fmt.Sprintf("%s/%s/json", c.baseURL, url.PathEscape(Normalize(name)))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, projectURL, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("get project: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get project: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("project '%s' %w", name, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("pypi get failed: status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+	var project Project
+	if err := json.Unmarshal(body, &project); err != nil {
+		return nil, fmt.Errorf("parse response: %w", err)
+	}
+	return &project, nil
+}
+
+// LatestVersion returns the version PyPI considers current: the newest
+// release that is neither yanked nor a pre-release.
+func (p *Project) LatestVersion() string {
+	return p.Info.Version
+}
+
+// Versions returns the releases that have at least one file that is not
+// yanked, oldest first by upload time.
+func (p *Project) Versions() []string {
+	uploaded := map[string]time.Time{}
+	for version, files := range p.Releases {
+		for _, f := range files {
+			if f.Yanked {
+				continue
+			}
+			if t, ok := uploaded[version]; !ok || f.UploadTime.Before(t) {
+				uploaded[version] = f.UploadTime
+			}
+		}
+	}
+
+	versions := make([]string, 0, len(uploaded))
+	for v := range uploaded {
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		ti, tj := uploaded[versions[i]], uploaded[versions[j]]
+		if ti.Equal(tj) {
+			return versions[i] < versions[j]
+		}
+		return ti.Before(tj)
+	})
+	return versions
+}
+
+// Uploaded returns when the first file of version was uploaded.
+func (p *Project) Uploaded(version string) (time.Time, bool) {
+	var first time.Time
+	for _, f := range p.Releases[version] {
+		if first.IsZero() || f.UploadTime.Before(first) {
+			first = f.UploadTime
+		}
+	}
+	return first, !first.IsZero()
+}
+
+// Artifact returns the file of version that uvx would most likely install:
+// a pure-Python wheel, else the source distribution, else any file.
+// Yanked files are skipped.
+func (p *Project) Artifact(version string) (File, bool) {
+	var sdist, other *File
+	for _, f := range p.Releases[version] {
+		switch {
+		case f.Yanked:
+			continue
+		case strings.HasSuffix(f.Filename, "-none-any.whl"):
+			return f, true
+		case f.PackageType == "sdist" && sdist == nil:
+			sdist = &f
+		case other == nil:
+			other = &f
+		}
+	}
+	if sdist != nil {
+		return *sdist, true
+	}
+	if other != nil {
+		return *other, true
+	}
+	return File{}, false
+}
+
+// URL returns the project's page on the index.
+func (p *Project) URL() string {
+	if p.Info.PackageURL != "" {
+		return p.Info.PackageURL
+	}
+	return "https://pypi.org/project/" + Normalize(p.Info.Name) + "/"
+}
+
+var separators = regexp.MustCompile(`[-_.]+`)
+
+// Normalize returns the PEP 503 form of a project name.
+func Normalize(name string) string {
+	return strings.ToLower(separators.ReplaceAllString(name, "-"))
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package pypi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+)
+
+const projectJSON = `{
+  "info": {
+    "name": "mcp-server-git",
+    "version": "0.6.2",
+    "summary": "A Model Context Protocol server providing tools to read, search, and manipulate Git repositories",
+    "license": "MIT",
+    "requires_python": ">=3.10",
+    "package_url": "https://pypi.org/project/mcp-server-git/",
+    "project_urls": {"Repository": "https://github.com/modelcontextprotocol/servers"}
+  },
+  "releases": {
+    "0.6.2": [
+      {"filename": "mcp_server_git-0.6.2.tar.gz", "packagetype": "sdist", "upload_time_iso_8601": "2025-01-10T00:00:00Z",
+       "digests": {"sha256": "00"}},
+      {"filename": "mcp_server_git-0.6.2-py3-none-any.whl", "packagetype": "bdist_wheel", "upload_time_iso_8601": "2025-01-10T00:00:00Z",
+       "url": "https://files.example/mcp_server_git-0.6.2-py3-none-any.whl",
+       "digests": {"sha256": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"}}
+    ],
+    "0.5.0": [{"filename": "mcp_server_git-0.5.0.tar.gz", "upload_time_iso_8601": "2024-11-01T00:00:00Z"}],
+    "0.5.1": [{"filename": "mcp_server_git-0.5.1.tar.gz", "upload_time_iso_8601": "2024-11-20T00:00:00Z", "yanked": true}],
+    "0.0.1": []
+  }
+}`
+
+func TestGetProject(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		if r.URL.Path != "/mcp-server-git/json" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(projectJSON))
+	}))
+	defer srv.Close()
+	client := NewClient(WithBaseURL(srv.URL + "/"))
+
+	project, err := client.GetProject(context.Background(), "MCP_Server.Git")
+	if err != nil {
+		t.Fatalf("GetProject() error = %v", err)
+	}
+	if gotPath != "/mcp-server-git/json" {
+		t.Errorf("requested %s, want the normalized name", gotPath)
+	}
+	if project.LatestVersion() != "0.6.2" {
+		t.Errorf("LatestVersion() = %q, want 0.6.2", project.LatestVersion())
+	}
+	if got, want := project.Versions(), []string{"0.5.0", "0.6.2"}; !slices.Equal(got, want) {
+		t.Errorf("Versions() = %v, want %v (yanked and empty releases dropped)", got, want)
+	}
+	if project.Info.ProjectURLs["Repository"] == "" {
+		t.Error("ProjectURLs not parsed")
+	}
+
+	file, ok := project.Artifact("0.6.2")
+	if !ok || file.Filename != "mcp_server_git-0.6.2-py3-none-any.whl" {
+		t.Errorf("Artifact(0.6.2) = %q, %v; want the pure-Python wheel", file.Filename, ok)
+	}
+	if got, want := file.SRI(), "sha256-n4bQgYhMfWWaL+qgxVrQFaO/TxsrC4Is0V1sFbDwCgg="; got != want {
+		t.Errorf("SRI() = %q, want %q", got, want)
+	}
+	if _, ok := project.Artifact("0.5.1"); ok {
+		t.Error("Artifact(0.5.1) found a file, want none: the only one is yanked")
+	}
+	if at, ok := project.Uploaded("0.5.0"); !ok || at.Month() != time.November {
+		t.Errorf("Uploaded(0.5.0) = %v, %v", at, ok)
+	}
+	if _, ok := project.Uploaded("9.9.9"); ok {
+		t.Error("Uploaded(9.9.9) ok = true, want false")
+	}
+
+	if _, err := client.GetProject(context.Background(), "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetProject(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"mcp-server-git":  "mcp-server-git",
+		"MCP_Server.Git":  "mcp-server-git",
+		"serena__agent--": "serena-agent-",
+	}
+	for in, want := range tests {
+		if got := Normalize(in); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
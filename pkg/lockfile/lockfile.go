@@ -0,0 +1,204 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package lockfile reads and writes mcp-plugin.lock, which records the exact
+// package version and tarball integrity of every npx and uvx server, so a
+// team can check that everyone runs the same servers.
+package lockfile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"sort"
+
+	"github.com/gizzahub/gzh-cli-mcp-plugin/internal/fsutil"
+)
+
+// FileName is the lockfile's name, next to the project's .mcp.json.
+const FileName = "mcp-plugin.lock"
+
+// formatVersion is bumped on incompatible changes to the file layout.
+const formatVersion = 1
+
+// Lockfile is the contents of mcp-plugin.lock.
+type Lockfile struct {
+	path string
+
+	Version int `json:"lockfile_version"`
+	// Scopes are the configuration scopes the lock covers; servers in other
+	// scopes are neither recorded nor verified.
+	Scopes []string `json:"scopes"`
+	// Servers is keyed by Key(scope, name).
+	Servers map[string]Entry `json:"servers"`
+}
+
+// Entry is one locked server.
+type Entry struct {
+	Name      string `json:"name"`
+	Scope     string `json:"scope"`
+	Registry  string `json:"registry"` // npm or pypi
+	Package   string `json:"package"`
+	Version   string `json:"version"`
+	Resolved  string `json:"resolved,omitempty"`  // tarball or file URL
+	Integrity string `json:"integrity,omitempty"` // Subresource Integrity, e.g. sha512-…
+}
+
+// Key identifies a server in the lock; the same name may exist in several
+// scopes.
+func Key(scope, name string) string {
+	return scope + "/" + name
+}
+
+// New returns an empty lock covering scopes, to be saved at path.
+func New(path string, scopes []string) *Lockfile {
+	return &Lockfile{path: path, Version: formatVersion, Scopes: scopes, Servers: map[string]Entry{}}
+}
+
+// Load reads the lock at path. A missing file is reported with an error
+// matching fs.ErrNotExist.
+func Load(path string) (*Lockfile, error) {
+	// #nosec G304 -- path is the project's lockfile or a user-provided flag
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	l := &Lockfile{path: path}
+	if err := json.Unmarshal(data, l); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if l.Version > formatVersion {
+		return nil, fmt.Errorf("%s has lockfile version %d; this mcp-plugin reads up to %d", path, l.Version, formatVersion)
+	}
+	if l.Servers == nil {
+		l.Servers = map[string]Entry{}
+	}
+	return l, nil
+}
+
+// Exists reports whether a lockfile is at path.
+func Exists(path string) bool {
+	_, err := os.Stat(path)
+	return !errors.Is(err, fs.ErrNotExist)
+}
+
+// Path returns where the lock is saved.
+func (l *Lockfile) Path() string { return l.path }
+
+// Covers reports whether the lock tracks scope.
+func (l *Lockfile) Covers(scope string) bool {
+	return slices.Contains(l.Scopes, scope)
+}
+
+// Save writes the lock atomically. Keys are sorted, so the file diffs
+// cleanly.
+func (l *Lockfile) Save() error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	// The lock is meant to be committed, so it is readable like .mcp.json.
+	return fsutil.WriteFile(l.path, append(data, '\n'), 0o644) // #nosec G306 -- shared project file
+}
+
+// Live is a server as the configuration declares it now.
+type Live struct {
+	Name     string
+	Scope    string
+	Registry string
+	Package  string
+	// Spec is the version as written: exact, a tag, a range or empty.
+	Spec string
+	// Pinned is set when Spec names exactly one version.
+	Pinned bool
+}
+
+// Status is the outcome of comparing one server with the lock.
+type Status string
+
+// Verify statuses. All but StatusOK and StatusFloating are drift.
+const (
+	StatusOK       Status = "ok"
+	StatusFloating Status = "floating" // not pinned; may not run the locked version
+	StatusChanged  Status = "changed"  // pinned to another version
+	StatusPackage  Status = "package"  // runs another package
+	StatusMissing  Status = "missing"  // locked but no longer configured
+	StatusUnlocked Status = "unlocked" // configured but not in the lock
+)
+
+// Finding is the verify result for one server.
+type Finding struct {
+	Name    string `json:"name"`
+	Scope   string `json:"scope"`
+	Status  Status `json:"status"`
+	Locked  string `json:"locked,omitempty"` // package@version in the lock
+	Live    string `json:"live,omitempty"`   // package@spec in the config
+	Message string `json:"message"`
+}
+
+// Drift reports whether f is a difference the lock does not allow.
+func (f Finding) Drift() bool {
+	return f.Status != StatusOK && f.Status != StatusFloating
+}
+
+// Verify compares the configured servers with the lock. Servers in scopes
+// the lock does not cover are ignored. Findings are sorted by scope and name.
+func (l *Lockfile) Verify(live []Live) []Finding {
+	var findings []Finding
+	seen := map[string]bool{}
+
+	for _, s := range live {
+		if !l.Covers(s.Scope) {
+			continue
+		}
+		key := Key(s.Scope, s.Name)
+		seen[key] = true
+		f := Finding{Name: s.Name, Scope: s.Scope, Live: spec(s.Package, s.Spec)}
+
+		locked, ok := l.Servers[key]
+		switch {
+		case !ok:
+			f.Status, f.Message = StatusUnlocked, "not in the lock; run 'mcp-plugin lock'"
+		case locked.Package != s.Package || locked.Registry != s.Registry:
+			f.Status, f.Message = StatusPackage, fmt.Sprintf("lock has %s package %s", locked.Registry, locked.Package)
+		case s.Spec == locked.Version:
+			f.Status, f.Message = StatusOK, "matches the lock"
+		case !s.Pinned:
+			f.Status, f.Message = StatusFloating, fmt.Sprintf("not pinned; lock has %s (run 'mcp-plugin pin')", locked.Version)
+		default:
+			f.Status, f.Message = StatusChanged, fmt.Sprintf("lock has %s", locked.Version)
+		}
+		if ok {
+			f.Locked = spec(locked.Package, locked.Version)
+		}
+		findings = append(findings, f)
+	}
+
+	for key, locked := range l.Servers {
+		if seen[key] || !l.Covers(locked.Scope) {
+			continue
+		}
+		findings = append(findings, Finding{
+			Name: locked.Name, Scope: locked.Scope, Status: StatusMissing,
+			Locked: spec(locked.Package, locked.Version), Message: "locked but not configured",
+		})
+	}
+
+	sort.Slice(findings, func(i, j int) bool {
+		if findings[i].Scope != findings[j].Scope {
+			return findings[i].Scope < findings[j].Scope
+		}
+		return findings[i].Name < findings[j].Name
+	})
+	return findings
+}
+
+func spec(pkg, version string) string {
+	if version == "" {
+		return pkg
+	}
+	return pkg + "@" + version
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package lockfile
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	if Exists(path) {
+		t.Fatal("Exists() = true before saving")
+	}
+	if _, err := Load(path); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("Load(missing) error = %v, want fs.ErrNotExist", err)
+	}
+
+	l := New(path, []string{"project"})
+	l.Servers[Key("project", "context7")] = Entry{
+		Name: "context7", Scope: "project", Registry: "npm",
+		Package: "@upstash/context7-mcp", Version: "1.0.14", Integrity: "sha512-abc",
+	}
+	if err := l.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := loaded.Servers["project/context7"]; got.Version != "1.0.14" || got.Integrity != "sha512-abc" {
+		t.Errorf("loaded entry = %+v", got)
+	}
+	if !loaded.Covers("project") || loaded.Covers("user") {
+		t.Errorf("Scopes = %v, want [project]", loaded.Scopes)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"lockfile_version": 99}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Load(newer version) error = nil, want refusal")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	l := New("", []string{"user", "project"})
+	for _, e := range []Entry{
+		{Name: "same", Scope: "project", Registry: "npm", Package: "a", Version: "1.0.0"},
+		{Name: "bumped", Scope: "project", Registry: "npm", Package: "b", Version: "1.0.0"},
+		{Name: "float", Scope: "project", Registry: "npm", Package: "c", Version: "1.0.0"},
+		{Name: "swapped", Scope: "user", Registry: "npm", Package: "d", Version: "1.0.0"},
+		{Name: "gone", Scope: "user", Registry: "pypi", Package: "e", Version: "0.1"},
+		{Name: "private", Scope: "local", Registry: "npm", Package: "f", Version: "1.0.0"},
+	} {
+		l.Servers[Key(e.Scope, e.Name)] = e
+	}
+
+	findings := l.Verify([]Live{
+		{Name: "same", Scope: "project", Registry: "npm", Package: "a", Spec: "1.0.0", Pinned: true},
+		{Name: "bumped", Scope: "project", Registry: "npm", Package: "b", Spec: "1.1.0", Pinned: true},
+		{Name: "float", Scope: "project", Registry: "npm", Package: "c", Spec: "^1.0.0"},
+		{Name: "swapped", Scope: "user", Registry: "npm", Package: "d-fork", Spec: "1.0.0", Pinned: true},
+		{Name: "new", Scope: "user", Registry: "npm", Package: "g"},
+		{Name: "ignored", Scope: "local", Registry: "npm", Package: "h"},
+	})
+
+	want := map[string]Status{
+		"project/same":   StatusOK,
+		"project/bumped": StatusChanged,
+		"project/float":  StatusFloating,
+		"user/swapped":   StatusPackage,
+		"user/new":       StatusUnlocked,
+		"user/gone":      StatusMissing,
+	}
+	if len(findings) != len(want) {
+		t.Fatalf("Verify() = %d findings, want %d: %+v", len(findings), len(want), findings)
+	}
+	drift := 0
+	for _, f := range findings {
+		if got := f.Status; got != want[Key(f.Scope, f.Name)] {
+			t.Errorf("%s/%s status = %s, want %s", f.Scope, f.Name, got, want[Key(f.Scope, f.Name)])
+		}
+		if f.Drift() {
+			drift++
+		}
+	}
+	if drift != 4 {
+		t.Errorf("drift = %d, want 4", drift)
+	}
+	if findings[0].Scope != "project" || findings[0].Name != "bumped" {
+		t.Errorf("findings not sorted: first is %s/%s", findings[0].Scope, findings[0].Name)
+	}
+}
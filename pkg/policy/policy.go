@@ -0,0 +1,253 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package policy restricts which MCP servers may be configured. A policy
+// file lists allowed and denied packages, allowed commands and URL hosts,
+// whether versions must be pinned and which headers are banned:
+//
+//	packages:
+//	  allow: ["@modelcontextprotocol/*", "@upstash/context7-mcp", "mcp-server-*"]
+//	  deny: ["*-unofficial"]
+//	commands: [npx, uvx]
+//	hosts: ["*.corp.example"]
+//	require_pinned: true
+//	banned_headers: [Cookie]
+//
+// Policies are read from a system-wide file and from the repository; a
+// server must satisfy every policy that exists.
+package policy
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SystemPathEnv overrides the location of the system-wide policy.
+const SystemPathEnv = "MCP_PLUGIN_POLICY"
+
+// RepoFileName is the per-repository policy, next to the project's .mcp.json.
+const RepoFileName = "mcp-plugin.policy.yaml"
+
+// SystemPath returns where the system-wide policy is read from:
+// $MCP_PLUGIN_POLICY, else /etc/mcp-plugin/policy.yaml, or
+// %ProgramData%\mcp-plugin\policy.yaml on Windows.
+func SystemPath() string {
+	if p := os.Getenv(SystemPathEnv); p != "" {
+		return p
+	}
+	if runtime.GOOS == "windows" {
+		dir := os.Getenv("ProgramData")
+		if dir == "" {
+			dir = `C:\ProgramData`
+		}
+		return filepath.Join(dir, "mcp-plugin", "policy.yaml")
+	}
+	return "/etc/mcp-plugin/policy.yaml"
+}
+
+// Policy is one policy file. Empty lists place no restriction.
+type Policy struct {
+	// Path is the file the policy was read from.
+	Path string `json:"-"`
+
+	Packages      Rules    `json:"packages"`
+	Commands      []string `json:"commands"`
+	Hosts         []string `json:"hosts"`
+	RequirePinned bool     `json:"require_pinned"`
+	BannedHeaders []string `json:"banned_headers"`
+}
+
+// Rules are allow and deny globs. Deny wins; when Allow is not empty,
+// anything it does not match is denied too.
+type Rules struct {
+	Allow []string `json:"allow"`
+	Deny  []string `json:"deny"`
+}
+
+// Set is the policies in effect.
+type Set []*Policy
+
+// Load reads the policies at paths, skipping files that do not exist.
+func Load(paths ...string) (Set, error) {
+	var set Set
+	for _, p := range paths {
+		// #nosec G304 -- policy locations are fixed or chosen by the administrator
+		data, err := os.ReadFile(p)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read policy: %w", err)
+		}
+		pol, err := Parse(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid policy %s: %w", p, err)
+		}
+		pol.Path = p
+		set = append(set, pol)
+	}
+	return set, nil
+}
+
+// Parse parses a YAML or JSON policy. Unknown keys are errors, so a typo
+// cannot silently disable a rule.
+func Parse(data []byte) (*Policy, error) {
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	pol := &Policy{}
+	if doc == nil {
+		return pol, nil
+	}
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported policy structure: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(pol); err != nil {
+		return nil, err
+	}
+
+	for _, patterns := range [][]string{pol.Packages.Allow, pol.Packages.Deny, pol.Commands, pol.Hosts} {
+		for _, p := range patterns {
+			if _, err := path.Match(p, ""); err != nil {
+				return nil, fmt.Errorf("bad pattern %q: %w", p, err)
+			}
+		}
+	}
+	return pol, nil
+}
+
+// Server is what the policy sees of a server.
+type Server struct {
+	Name  string
+	Scope string
+	// Command is the program run, after any `mcp-plugin exec` wrapper.
+	Command string
+	// Package is the npm or PyPI package of an npx or uvx server, and
+	// Pinned whether it names an exact version.
+	Package string
+	Pinned  bool
+	URL     string
+	Headers []string
+}
+
+// Rule names what a violation breaks.
+type Rule string
+
+// Rules.
+const (
+	RulePackageDenied   Rule = "package-denied"
+	RulePackageNotAllow Rule = "package-not-allowed"
+	RuleCommand         Rule = "command"
+	RuleHost            Rule = "host"
+	RuleUnpinned        Rule = "unpinned"
+	RuleHeader          Rule = "header"
+)
+
+// Violation is one broken rule.
+type Violation struct {
+	Server  string `json:"server"`
+	Scope   string `json:"scope,omitempty"`
+	Rule    Rule   `json:"rule"`
+	Message string `json:"message"`
+	Policy  string `json:"policy"`
+}
+
+func (v Violation) Error() string {
+	return fmt.Sprintf("%s: %s (%s)", v.Server, v.Message, v.Policy)
+}
+
+// Check returns the rules s breaks in every policy of the set.
+func (set Set) Check(s Server) []Violation {
+	var violations []Violation
+	for _, p := range set {
+		violations = append(violations, p.Check(s)...)
+	}
+	return violations
+}
+
+// Check returns the rules of p that s breaks.
+func (p *Policy) Check(s Server) []Violation {
+	var violations []Violation
+	add := func(rule Rule, format string, args ...any) {
+		violations = append(violations, Violation{
+			Server: s.Name, Scope: s.Scope, Rule: rule, Message: fmt.Sprintf(format, args...), Policy: p.Path,
+		})
+	}
+
+	if s.Package != "" {
+		if pattern, ok := match(p.Packages.Deny, s.Package); ok {
+			add(RulePackageDenied, "package %s is denied (%s)", s.Package, pattern)
+		} else if _, ok := match(p.Packages.Allow, s.Package); len(p.Packages.Allow) > 0 && !ok {
+			add(RulePackageNotAllow, "package %s is not in the allowed packages", s.Package)
+		}
+		if p.RequirePinned && !s.Pinned {
+			add(RuleUnpinned, "package %s is not pinned to an exact version", s.Package)
+		}
+	}
+
+	if s.Command != "" && len(p.Commands) > 0 {
+		// A bare name matches by program name (npx.exe is npx). A command
+		// with a path must match in full, or any binary named npx would pass.
+		_, ok := match(p.Commands, s.Command)
+		if !ok && !strings.ContainsAny(s.Command, `/\`) {
+			_, ok = match(p.Commands, commandName(s.Command))
+		}
+		if !ok {
+			add(RuleCommand, "command %s is not allowed", s.Command)
+		}
+	}
+
+	if s.URL != "" && len(p.Hosts) > 0 {
+		u, err := url.Parse(s.URL)
+		host := ""
+		if err == nil {
+			host = strings.ToLower(u.Hostname())
+		}
+		if _, ok := match(p.Hosts, host); !ok {
+			add(RuleHost, "host %s is not allowed", cmp.Or(host, s.URL))
+		}
+	}
+
+	for _, h := range s.Headers {
+		if slices.ContainsFunc(p.BannedHeaders, func(b string) bool { return strings.EqualFold(b, h) }) {
+			add(RuleHeader, "header %s is banned", h)
+		}
+	}
+	return violations
+}
+
+// commandName is the program name of a command path written for any OS:
+// C:\tools\npx.exe and /usr/bin/npx are both "npx".
+func commandName(command string) string {
+	name := command[strings.LastIndexAny(command, `/\`)+1:]
+	if ext := path.Ext(name); strings.EqualFold(ext, ".exe") {
+		name = strings.TrimSuffix(name, ext)
+	}
+	return name
+}
+
+func match(patterns []string, name string) (string, bool) {
+	for _, p := range patterns {
+		if ok, _ := path.Match(strings.ToLower(p), strings.ToLower(name)); ok {
+			return p, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const teamPolicy = `
+packages:
+  allow: ["@modelcontextprotocol/*", "@upstash/context7-mcp", "mcp-server-*"]
+  deny: ["@modelcontextprotocol/server-puppeteer"]
+commands: [npx, uvx, mcp-plugin]
+hosts: ["*.corp.example", "mcp.notion.com"]
+require_pinned: true
+banned_headers: [cookie]
+`
+
+func TestCheck(t *testing.T) {
+	pol, err := Parse([]byte(teamPolicy))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	pol.Path = "team.yaml"
+
+	tests := []struct {
+		name   string
+		server Server
+		want   []Rule
+	}{
+		{"allowed and pinned", Server{Command: "npx", Package: "@upstash/context7-mcp", Pinned: true}, nil},
+		{"allowed by glob", Server{Command: "uvx", Package: "MCP-Server-Git", Pinned: true}, nil},
+		{"denied wins over allow", Server{Command: "npx", Package: "@modelcontextprotocol/server-puppeteer", Pinned: true}, []Rule{RulePackageDenied}},
+		{"not allowed and unpinned", Server{Command: "npx", Package: "random-mcp"}, []Rule{RulePackageNotAllow, RuleUnpinned}},
+		{"command", Server{Command: "docker"}, []Rule{RuleCommand}},
+		{"windows command", Server{Command: "npx.exe", Package: "mcp-server-time", Pinned: true}, nil},
+		{"path named like an allowed command", Server{Command: "/tmp/x/npx", Package: "mcp-server-time", Pinned: true}, []Rule{RuleCommand}},
+		{"windows path named like an allowed command", Server{Command: `C:\tools\npx.exe`, Package: "mcp-server-time", Pinned: true}, []Rule{RuleCommand}},
+		{"allowed host", Server{URL: "https://mcp.api.corp.example/v1"}, nil},
+		{"host and header", Server{URL: "https://evil.example/mcp", Headers: []string{"Authorization", "Cookie"}}, []Rule{RuleHost, RuleHeader}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Set{pol}.Check(tt.server)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Check() = %+v, want rules %v", got, tt.want)
+			}
+			for i, v := range got {
+				if v.Rule != tt.want[i] || v.Policy != "team.yaml" {
+					t.Errorf("violation %d = %+v, want rule %s", i, v, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, doc := range []string{
+		"require_pined: true",         // typo
+		"packages: {allow: ['[abc']}", // bad glob
+		"commands: npx",               // not a list
+	} {
+		if _, err := Parse([]byte(doc)); err == nil {
+			t.Errorf("Parse(%q) error = nil, want error", doc)
+		}
+	}
+	if pol, err := Parse(nil); err != nil || pol.RequirePinned {
+		t.Errorf("Parse(empty) = %+v, %v; want no restrictions", pol, err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	system := filepath.Join(dir, "system.yaml")
+	repo := filepath.Join(dir, RepoFileName)
+	if err := os.WriteFile(system, []byte("commands: [npx]"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(repo, []byte("require_pinned: true"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	set, err := Load(system, filepath.Join(dir, "missing.yaml"), repo)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(set) != 2 || set[0].Path != system || set[1].Path != repo {
+		t.Fatalf("Load() = %+v", set)
+	}
+	// Every policy applies: the repository cannot loosen the system policy.
+	got := set.Check(Server{Command: "uvx", Package: "mcp-server-git"})
+	if len(got) != 2 || got[0].Rule != RuleCommand || got[1].Rule != RuleUnpinned {
+		t.Errorf("Check() = %+v, want command and unpinned", got)
+	}
+
+	t.Setenv(SystemPathEnv, system)
+	if SystemPath() != system {
+		t.Errorf("SystemPath() = %s, want %s", SystemPath(), system)
+	}
+}
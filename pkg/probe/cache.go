@@ -0,0 +1,126 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package probe
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gizzahub/gzh-cli-mcp-plugin/internal/fsutil"
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/config"
+)
+
+// Cache remembers the last inventory of each server, so listings can show
+// what a server offers without starting it. An entry is ignored once the
+// server's command or URL changes.
+type Cache struct {
+	path    string
+	Servers map[string]CacheEntry `json:"servers"`
+}
+
+// CacheEntry is one cached inventory.
+type CacheEntry struct {
+	Fingerprint string     `json:"fingerprint"`
+	CheckedAt   time.Time  `json:"checked_at"`
+	Inventory   *Inventory `json:"inventory"`
+}
+
+// LoadCache reads the cache at path. A missing file is an empty cache.
+func LoadCache(path string) (*Cache, error) {
+	// #nosec G304 -- path is under the mcp-plugin cache directory
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		data = nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read tool cache: %w", err)
+	}
+	return parseCache(path, data)
+}
+
+// UpdateCache runs change on the cache at path and saves it, holding a lock
+// throughout so concurrent runs keep each other's entries.
+func UpdateCache(path string, change func(*Cache)) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return fsutil.Update(path, 0o600, func(current []byte) ([]byte, error) {
+		c, err := parseCache(path, current)
+		if err != nil {
+			return nil, err
+		}
+		change(c)
+		return c.marshal()
+	})
+}
+
+// parseCache decodes the cache at path from data; nil data is an empty cache.
+func parseCache(path string, data []byte) (*Cache, error) {
+	c := &Cache{path: path, Servers: map[string]CacheEntry{}}
+	if data == nil {
+		return c, nil
+	}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("failed to parse tool cache %s: %w", path, err)
+	}
+	if c.Servers == nil {
+		c.Servers = map[string]CacheEntry{}
+	}
+	return c, nil
+}
+
+// Lookup returns the cached inventory of server, if it is still current.
+func (c *Cache) Lookup(server config.MCPServer) (CacheEntry, bool) {
+	entry, ok := c.Servers[cacheKey(server)]
+	if !ok || entry.Fingerprint != fingerprint(server) || entry.Inventory == nil {
+		return CacheEntry{}, false
+	}
+	return entry, true
+}
+
+// Store records inv as the current inventory of server.
+func (c *Cache) Store(server config.MCPServer, inv *Inventory, at time.Time) {
+	c.Servers[cacheKey(server)] = CacheEntry{Fingerprint: fingerprint(server), CheckedAt: at, Inventory: inv}
+}
+
+// Save writes the cache atomically, replacing what other runs stored in the
+// meantime; UpdateCache keeps their entries.
+func (c *Cache) Save() error {
+	data, err := c.marshal()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o700); err != nil {
+		return err
+	}
+	return fsutil.WriteFile(c.path, data, 0o600)
+}
+
+func (c *Cache) marshal() ([]byte, error) {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// cacheKey identifies a server across runs; the same name may exist in
+// several scopes.
+func cacheKey(server config.MCPServer) string {
+	return server.Source + "/" + server.Name
+}
+
+// fingerprint hashes what decides which server is reached. Env and header
+// values are left out: they mostly carry credentials, which rotate.
+func fingerprint(server config.MCPServer) string {
+	data, _ := json.Marshal([]any{server.Type, server.Command, server.Args, server.URL})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
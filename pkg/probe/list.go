@@ -0,0 +1,108 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package probe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Inventory is everything a server exposes, as listed in one session.
+type Inventory struct {
+	Server          string     `json:"server"`
+	Version         string     `json:"version,omitempty"`
+	ProtocolVersion string     `json:"protocol_version"`
+	Tools           []Tool     `json:"tools"`
+	Prompts         []Prompt   `json:"prompts"`
+	Resources       []Resource `json:"resources"`
+}
+
+// Tool is a callable tool and the JSON Schema of its input.
+type Tool struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	InputSchema json.RawMessage `json:"input_schema,omitempty"`
+}
+
+// Prompt is a prompt template the server offers.
+type Prompt struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description,omitempty"`
+	Arguments   []PromptArgument `json:"arguments,omitempty"`
+}
+
+// PromptArgument is one argument of a prompt template.
+type PromptArgument struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+// Resource is a piece of data the server can return by URI.
+type Resource struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	MIMEType    string `json:"mime_type,omitempty"`
+}
+
+// List connects to server like Handshake and calls tools/list, prompts/list
+// and resources/list for the capabilities it advertises.
+func List(ctx context.Context, server config.MCPServer) (*Inventory, error) {
+	inv := &Inventory{Tools: []Tool{}, Prompts: []Prompt{}, Resources: []Resource{}}
+	err := withSession(ctx, server, func(session *mcp.ClientSession) error {
+		init := session.InitializeResult()
+		inv.ProtocolVersion = init.ProtocolVersion
+		if init.ServerInfo != nil {
+			inv.Server = init.ServerInfo.Name
+			inv.Version = init.ServerInfo.Version
+		}
+		caps := init.Capabilities
+		if caps == nil {
+			return nil
+		}
+
+		if caps.Tools != nil {
+			for t, err := range session.Tools(ctx, nil) {
+				if err != nil {
+					return fmt.Errorf("tools/list failed: %w", err)
+				}
+				schema, err := json.Marshal(t.InputSchema)
+				if err != nil {
+					return fmt.Errorf("tool %s: invalid input schema: %w", t.Name, err)
+				}
+				inv.Tools = append(inv.Tools, Tool{Name: t.Name, Description: t.Description, InputSchema: schema})
+			}
+		}
+		if caps.Prompts != nil {
+			for p, err := range session.Prompts(ctx, nil) {
+				if err != nil {
+					return fmt.Errorf("prompts/list failed: %w", err)
+				}
+				prompt := Prompt{Name: p.Name, Description: p.Description}
+				for _, a := range p.Arguments {
+					prompt.Arguments = append(prompt.Arguments, PromptArgument{Name: a.Name, Description: a.Description, Required: a.Required})
+				}
+				inv.Prompts = append(inv.Prompts, prompt)
+			}
+		}
+		if caps.Resources != nil {
+			for r, err := range session.Resources(ctx, nil) {
+				if err != nil {
+					return fmt.Errorf("resources/list failed: %w", err)
+				}
+				inv.Resources = append(inv.Resources, Resource{URI: r.URI, Name: r.Name, Description: r.Description, MIMEType: r.MIMEType})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
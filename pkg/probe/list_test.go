@@ -0,0 +1,107 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package probe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestList(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	inv, err := List(ctx, stdioServer("ok"))
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if inv.Server != "test-server" {
+		t.Errorf("Server = %s, want test-server", inv.Server)
+	}
+	if len(inv.Tools) != 2 || inv.Tools[0].Name != "echo" || inv.Tools[0].Description != "Echo text" {
+		t.Fatalf("Tools = %+v", inv.Tools)
+	}
+	var schema struct {
+		Properties map[string]any `json:"properties"`
+	}
+	if err := json.Unmarshal(inv.Tools[0].InputSchema, &schema); err != nil || schema.Properties["text"] == nil {
+		t.Errorf("InputSchema = %s, want a text property", inv.Tools[0].InputSchema)
+	}
+	if len(inv.Prompts) != 1 || inv.Prompts[0].Name != "review" || !inv.Prompts[0].Arguments[0].Required {
+		t.Errorf("Prompts = %+v", inv.Prompts)
+	}
+	if len(inv.Resources) != 1 || inv.Resources[0].URI != "test://readme" {
+		t.Errorf("Resources = %+v", inv.Resources)
+	}
+}
+
+func TestCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tools.json")
+	server := stdioServer("ok")
+	server.Name, server.Source = "test", "user"
+
+	cache, err := LoadCache(path)
+	if err != nil {
+		t.Fatalf("LoadCache() error = %v", err)
+	}
+	if _, ok := cache.Lookup(server); ok {
+		t.Fatal("Lookup() on empty cache found an entry")
+	}
+
+	cache.Store(server, &Inventory{Server: "test-server", Tools: []Tool{{Name: "echo"}}}, time.Now())
+	if err := cache.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	reloaded, err := LoadCache(path)
+	if err != nil {
+		t.Fatalf("LoadCache() error = %v", err)
+	}
+	entry, ok := reloaded.Lookup(server)
+	if !ok || len(entry.Inventory.Tools) != 1 {
+		t.Fatalf("Lookup() = %+v, %v; want the stored inventory", entry, ok)
+	}
+
+	other := server
+	other.Source = "project"
+	if _, ok := reloaded.Lookup(other); ok {
+		t.Error("Lookup() matched a server from another scope")
+	}
+	changed := server
+	changed.Args = []string{"--other"}
+	if _, ok := reloaded.Lookup(changed); ok {
+		t.Error("Lookup() matched a server whose command changed")
+	}
+}
+
+func TestUpdateCache_Concurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tools.json")
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			server := stdioServer("ok")
+			server.Name, server.Source = fmt.Sprintf("s%d", i), "user"
+			err := UpdateCache(path, func(c *Cache) {
+				c.Store(server, &Inventory{Server: server.Name}, time.Now())
+			})
+			if err != nil {
+				t.Errorf("UpdateCache() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	cache, err := LoadCache(path)
+	if err != nil || len(cache.Servers) != 8 {
+		t.Errorf("cache holds %d servers (%v), want all 8", len(cache.Servers), err)
+	}
+}
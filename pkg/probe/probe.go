@@ -0,0 +1,283 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package probe performs one-shot MCP handshakes against configured servers.
+//
+// It is a diagnostic: a probe starts or connects to a server, runs the
+// initialize handshake through the official MCP Go SDK, asks what it offers
+// and disconnects. Claude Code remains the only thing that runs servers for
+// real.
+package probe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gizzahub/gzh-cli-mcp-plugin/internal/version"
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// terminateAfter is how long a stdio server gets to exit after its stdin is
+// closed before it is signalled.
+const terminateAfter = 2 * time.Second
+
+// Errors classifying why an HTTP server failed the handshake.
+var (
+	// ErrUnreachable means no HTTP response arrived at all.
+	ErrUnreachable = errors.New("unreachable")
+	// ErrUnauthorized means the server rejected the request's credentials.
+	ErrUnauthorized = errors.New("authentication failed")
+	// ErrNotMCP means something answered, but not as an MCP endpoint.
+	ErrNotMCP = errors.New("not an MCP endpoint")
+)
+
+// Result describes what a server reported during the handshake.
+type Result struct {
+	Server          string   `json:"server"`
+	Version         string   `json:"version,omitempty"`
+	ProtocolVersion string   `json:"protocol_version"`
+	Capabilities    []string `json:"capabilities"`
+	Tools           int      `json:"tools"`
+}
+
+// Handshake connects to server the way Claude Code would, completes the
+// initialize handshake, counts the tools it offers and disconnects.
+//
+// Command servers are started with their args, and env added to the current
+// environment; ${VAR} and ${VAR:-default} are expanded the way Claude Code
+// does, and the process is killed if ctx ends first. HTTP servers get their
+// configured headers on every request, over Streamable HTTP (JSON or
+// text/event-stream responses) or, for "sse" servers, the older HTTP+SSE
+// transport. HTTP failures wrap ErrUnreachable, ErrUnauthorized or ErrNotMCP
+// when the HTTP exchange explains them.
+func Handshake(ctx context.Context, server config.MCPServer) (*Result, error) {
+	var result *Result
+	err := withSession(ctx, server, func(session *mcp.ClientSession) error {
+		init := session.InitializeResult()
+		result = &Result{ProtocolVersion: init.ProtocolVersion}
+		if init.ServerInfo != nil {
+			result.Server = init.ServerInfo.Name
+			result.Version = init.ServerInfo.Version
+		}
+		if init.Capabilities == nil {
+			return nil
+		}
+		result.Capabilities = capabilityNames(init.Capabilities)
+		if init.Capabilities.Tools != nil {
+			for _, err := range session.Tools(ctx, nil) {
+				if err != nil {
+					return fmt.Errorf("tools/list failed: %w", err)
+				}
+				result.Tools++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// withSession connects to server, runs fn and shuts the session down.
+func withSession(ctx context.Context, server config.MCPServer, fn func(*mcp.ClientSession) error) error {
+	switch {
+	case server.URL != "":
+		// The SDK detaches its requests from ctx so sessions can outlive
+		// Connect; a probe must not, so requests are bound to ctx again.
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		rec := newRecorder(ctx, server.Headers)
+		client := &http.Client{Transport: rec}
+		var transport mcp.Transport = &mcp.StreamableClientTransport{
+			Endpoint:   expand(server.URL),
+			HTTPClient: client,
+			MaxRetries: -1,
+		}
+		if server.Type == config.TypeSSE {
+			transport = &mcp.SSEClientTransport{Endpoint: expand(server.URL), HTTPClient: client}
+		}
+		return rec.classify(ctx, run(ctx, transport, fn))
+
+	case server.Command != "":
+		cmd := exec.CommandContext(ctx, expand(server.Command), expandAll(server.Args)...)
+		cmd.Env = os.Environ()
+		for k, v := range server.Env {
+			cmd.Env = append(cmd.Env, k+"="+expand(v))
+		}
+		stderr := &tailWriter{}
+		cmd.Stderr = stderr
+
+		err := run(ctx, &mcp.CommandTransport{Command: cmd, TerminateDuration: terminateAfter}, fn)
+		if line := stderr.lastLine(); err != nil && line != "" {
+			return fmt.Errorf("%w (stderr: %s)", err, line)
+		}
+		return err
+
+	default:
+		return errors.New("server has neither a command nor a URL")
+	}
+}
+
+func run(ctx context.Context, transport mcp.Transport, fn func(*mcp.ClientSession) error) error {
+	client := mcp.NewClient(&mcp.Implementation{Name: "mcp-plugin", Version: version.Version}, nil)
+
+	session, err := client.Connect(ctx, transport, nil)
+	if err != nil {
+		switch {
+		case errors.Is(ctx.Err(), context.DeadlineExceeded):
+			return errors.New("no initialize response before timeout")
+		case errors.Is(ctx.Err(), context.Canceled):
+			return errors.New("cancelled")
+		}
+		return err
+	}
+	defer func() { _ = session.Close() }()
+
+	return fn(session)
+}
+
+func capabilityNames(caps *mcp.ServerCapabilities) []string {
+	var names []string
+	if caps.Tools != nil {
+		names = append(names, "tools")
+	}
+	if caps.Prompts != nil {
+		names = append(names, "prompts")
+	}
+	if caps.Resources != nil {
+		names = append(names, "resources")
+	}
+	if caps.Logging != nil {
+		names = append(names, "logging")
+	}
+	if caps.Completions != nil {
+		names = append(names, "completions")
+	}
+	return names
+}
+
+// recorder adds the configured headers to each request and remembers how the
+// server answered the first one, which is what explains a failed handshake.
+type recorder struct {
+	ctx     context.Context
+	headers map[string]string
+
+	mu          sync.Mutex
+	status      int
+	contentType string
+	err         error
+}
+
+func newRecorder(ctx context.Context, headers map[string]string) *recorder {
+	expanded := make(map[string]string, len(headers))
+	for k, v := range headers {
+		expanded[k] = expand(v)
+	}
+	return &recorder{ctx: ctx, headers: expanded}
+}
+
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqCtx, cancel := context.WithCancel(req.Context())
+	context.AfterFunc(r.ctx, cancel)
+	req = req.Clone(reqCtx)
+	for k, v := range r.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultTransport.RoundTrip(req)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch {
+	case err != nil && r.err == nil && r.status == 0:
+		r.err = err
+	case err == nil && r.status == 0:
+		r.status = resp.StatusCode
+		r.contentType = resp.Header.Get("Content-Type")
+	}
+	return resp, err
+}
+
+// classify explains err by the first HTTP response. Without one, the server
+// is unreachable unless ctx ran out first, in which case err says so.
+func (r *recorder) classify(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch {
+	case r.status == 0 && r.err != nil && ctx.Err() == nil:
+		return fmt.Errorf("%w (%v)", ErrUnreachable, r.err)
+	case r.status == http.StatusUnauthorized || r.status == http.StatusForbidden:
+		return fmt.Errorf("%w (HTTP %d)", ErrUnauthorized, r.status)
+	case r.status >= 400:
+		return fmt.Errorf("%w (HTTP %d)", ErrNotMCP, r.status)
+	case r.status != 0 && !isMCPContentType(r.contentType):
+		return fmt.Errorf("%w (Content-Type %q)", ErrNotMCP, r.contentType)
+	}
+	return err
+}
+
+func isMCPContentType(contentType string) bool {
+	return strings.HasPrefix(contentType, "application/json") ||
+		strings.HasPrefix(contentType, "text/event-stream")
+}
+
+var envRef = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// expand replaces ${VAR} and ${VAR:-default} from the environment.
+func expand(s string) string {
+	return envRef.ReplaceAllStringFunc(s, func(m string) string {
+		sub := envRef.FindStringSubmatch(m)
+		if v, ok := os.LookupEnv(sub[1]); ok && v != "" {
+			return v
+		}
+		return sub[3]
+	})
+}
+
+func expandAll(values []string) []string {
+	expanded := make([]string, len(values))
+	for i, v := range values {
+		expanded[i] = expand(v)
+	}
+	return expanded
+}
+
+// tailWriter keeps the last few kilobytes written to it, so a failing server's
+// final error message can be reported without buffering unbounded output.
+type tailWriter struct {
+	mu  sync.Mutex
+	buf []byte
+}
+
+const tailSize = 4096
+
+func (w *tailWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf = append(w.buf, p...)
+	if len(w.buf) > tailSize {
+		w.buf = w.buf[len(w.buf)-tailSize:]
+	}
+	return len(p), nil
+}
+
+func (w *tailWriter) lastLine() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	lines := strings.Split(strings.TrimSpace(string(w.buf)), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
@@ -0,0 +1,240 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package probe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gizzahub/gzh-cli-mcp-plugin/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// serverModeEnv makes the test binary act as a stdio MCP server, so Stdio
+// can be exercised against a real process.
+const serverModeEnv = "PROBE_TEST_SERVER"
+
+func TestMain(m *testing.M) {
+	switch os.Getenv(serverModeEnv) {
+	case "ok":
+		os.Exit(runTestServer())
+	case "fail":
+		fmt.Fprintln(os.Stderr, "Error: Cannot find module 'missing-dep'")
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
+
+type echoArgs struct {
+	Text string `json:"text"`
+}
+
+func newTestServer() *mcp.Server {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "1.2.3"}, nil)
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, args echoArgs) (*mcp.CallToolResult, any, error) {
+		return nil, nil, nil
+	}
+	mcp.AddTool(server, &mcp.Tool{Name: "echo", Description: "Echo text"}, handler)
+	mcp.AddTool(server, &mcp.Tool{Name: "shout", Description: "Echo loudly"}, handler)
+	server.AddPrompt(&mcp.Prompt{
+		Name:      "review",
+		Arguments: []*mcp.PromptArgument{{Name: "file", Required: true}},
+	}, func(context.Context, *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		return &mcp.GetPromptResult{}, nil
+	})
+	server.AddResource(&mcp.Resource{URI: "test://readme", Name: "readme", MIMEType: "text/plain"},
+		func(context.Context, *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+			return &mcp.ReadResourceResult{}, nil
+		})
+	return server
+}
+
+func runTestServer() int {
+	if err := newTestServer().Run(context.Background(), &mcp.StdioTransport{}); err != nil {
+		return 1
+	}
+	return 0
+}
+
+// requireToken rejects requests without the test bearer token.
+func requireToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func stdioServer(mode string) config.MCPServer {
+	return config.MCPServer{
+		Type:    config.TypeStdio,
+		Command: os.Args[0],
+		Env:     map[string]string{serverModeEnv: mode},
+	}
+}
+
+func TestHandshake_Stdio(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := Handshake(ctx, stdioServer("ok"))
+	if err != nil {
+		t.Fatalf("Handshake() error = %v", err)
+	}
+	if result.Server != "test-server" || result.Version != "1.2.3" {
+		t.Errorf("server = %s %s, want test-server 1.2.3", result.Server, result.Version)
+	}
+	if result.Tools != 2 {
+		t.Errorf("Tools = %d, want 2", result.Tools)
+	}
+	if !slices.Contains(result.Capabilities, "tools") {
+		t.Errorf("Capabilities = %v, want tools", result.Capabilities)
+	}
+	if result.ProtocolVersion == "" {
+		t.Error("ProtocolVersion is empty")
+	}
+}
+
+func TestHandshake_StdioFailure(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := Handshake(ctx, stdioServer("fail"))
+	if err == nil {
+		t.Fatal("Handshake() error = nil, want failure")
+	}
+	if !strings.Contains(err.Error(), "missing-dep") {
+		t.Errorf("error = %v, want server stderr included", err)
+	}
+}
+
+func TestHandshake_HTTP(t *testing.T) {
+	getServer := func(*http.Request) *mcp.Server { return newTestServer() }
+	for _, jsonResponse := range []bool{false, true} {
+		handler := mcp.NewStreamableHTTPHandler(getServer, &mcp.StreamableHTTPOptions{JSONResponse: jsonResponse})
+		srv := httptest.NewServer(requireToken(handler))
+		t.Cleanup(srv.Close)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		result, err := Handshake(ctx, config.MCPServer{
+			Type:    config.TypeHTTP,
+			URL:     srv.URL,
+			Headers: map[string]string{"Authorization": "Bearer ${PROBE_TOKEN:-secret}"},
+		})
+		cancel()
+		if err != nil {
+			t.Fatalf("Handshake(json=%v) error = %v", jsonResponse, err)
+		}
+		if result.Server != "test-server" || result.Tools != 2 || result.ProtocolVersion == "" {
+			t.Errorf("Handshake(json=%v) = %+v", jsonResponse, result)
+		}
+	}
+}
+
+func TestHandshake_SSE(t *testing.T) {
+	handler := mcp.NewSSEHandler(func(*http.Request) *mcp.Server { return newTestServer() }, nil)
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	result, err := Handshake(ctx, config.MCPServer{Type: config.TypeSSE, URL: srv.URL})
+	if err != nil {
+		t.Fatalf("Handshake() error = %v", err)
+	}
+	if result.Server != "test-server" || result.Tools != 2 {
+		t.Errorf("Handshake() = %+v", result)
+	}
+}
+
+func TestHandshake_HTTPFailures(t *testing.T) {
+	mcpHandler := mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server { return newTestServer() }, nil)
+	authed := httptest.NewServer(requireToken(mcpHandler))
+	defer authed.Close()
+	notFound := httptest.NewServer(http.NotFoundHandler())
+	defer notFound.Close()
+	html := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<html></html>"))
+	}))
+	defer html.Close()
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	tests := []struct {
+		name    string
+		url     string
+		headers map[string]string
+		want    error
+	}{
+		{"missing token", authed.URL, nil, ErrUnauthorized},
+		{"wrong token", authed.URL, map[string]string{"Authorization": "Bearer nope"}, ErrUnauthorized},
+		{"not found", notFound.URL, nil, ErrNotMCP},
+		{"web page", html.URL, nil, ErrNotMCP},
+		{"connection refused", closed.URL, nil, ErrUnreachable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			_, err := Handshake(ctx, config.MCPServer{Type: config.TypeHTTP, URL: tt.url, Headers: tt.headers})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Handshake() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpand(t *testing.T) {
+	t.Setenv("PROBE_SET", "value")
+	t.Setenv("PROBE_EMPTY", "")
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"${PROBE_SET}", "value"},
+		{"pre-${PROBE_SET}-post", "pre-value-post"},
+		{"${PROBE_UNSET_VAR}", ""},
+		{"${PROBE_UNSET_VAR:-fallback}", "fallback"},
+		{"${PROBE_EMPTY:-fallback}", "fallback"},
+		{"$PROBE_SET", "$PROBE_SET"},
+	}
+	for _, tt := range tests {
+		if got := expand(tt.in); got != tt.want {
+			t.Errorf("expand(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestHandshake_HTTPTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := Handshake(ctx, config.MCPServer{Type: config.TypeHTTP, URL: srv.URL})
+	if err == nil || errors.Is(err, ErrUnreachable) {
+		t.Errorf("Handshake() error = %v, want a timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Handshake() took %v, want it to stop at the deadline", elapsed)
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package provenance
+
+// KnownPackages are widely used MCP server packages on npm and PyPI, the
+// names typosquats are most likely to imitate.
+var KnownPackages = []string{
+	// npm
+	"@modelcontextprotocol/server-everything",
+	"@modelcontextprotocol/server-filesystem",
+	"@modelcontextprotocol/server-github",
+	"@modelcontextprotocol/server-gitlab",
+	"@modelcontextprotocol/server-google-maps",
+	"@modelcontextprotocol/server-memory",
+	"@modelcontextprotocol/server-postgres",
+	"@modelcontextprotocol/server-puppeteer",
+	"@modelcontextprotocol/server-sequential-thinking",
+	"@modelcontextprotocol/server-slack",
+	"@modelcontextprotocol/server-brave-search",
+	"@modelcontextprotocol/inspector",
+	"@upstash/context7-mcp",
+	"@playwright/mcp",
+	"@notionhq/notion-mcp-server",
+	"@sentry/mcp-server",
+	"@supabase/mcp-server-supabase",
+	"@browsermcp/mcp",
+	"chrome-devtools-mcp",
+	"figma-developer-mcp",
+	"firecrawl-mcp",
+	"tavily-mcp",
+	// PyPI
+	"mcp-server-fetch",
+	"mcp-server-git",
+	"mcp-server-time",
+	"mcp-server-sqlite",
+	"awslabs.aws-documentation-mcp-server",
+	"serena-agent",
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package provenance flags supply-chain risks of a package release from its
+// registry metadata, before a server that runs it is written to the
+// configuration.
+package provenance
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Release is what the registry says about the version about to be run.
+// Fields a registry does not provide are left empty and their checks skipped.
+type Release struct {
+	Registry string // npm or pypi
+	Name     string
+	Version  string
+
+	Published time.Time
+	// Publishers are the accounts that could publish this version: its
+	// maintainers and the account that did.
+	Publishers []string
+	// PreviousVersion and PreviousPublishers describe the release before
+	// this one; PreviousPublishers is nil when unknown.
+	PreviousVersion    string
+	PreviousPublishers []string
+
+	Repository     string
+	InstallScripts []string
+	Deprecated     string
+}
+
+// Kind names a check.
+type Kind string
+
+// Checks.
+const (
+	KindRecent        Kind = "recent"
+	KindMaintainers   Kind = "maintainers"
+	KindRepository    Kind = "repository"
+	KindInstallScript Kind = "install-script"
+	KindDeprecated    Kind = "deprecated"
+	KindTyposquat     Kind = "typosquat"
+	// KindUnverified is for callers to report metadata they could not fetch.
+	KindUnverified Kind = "unverified"
+)
+
+// Finding is one flagged risk.
+type Finding struct {
+	Kind    Kind   `json:"kind"`
+	Message string `json:"message"`
+}
+
+// Options tune the checks.
+type Options struct {
+	// MinAge flags versions published more recently than this; zero
+	// disables the check.
+	MinAge time.Duration
+	// Known are well-known package names that typosquats imitate; nil uses
+	// KnownPackages.
+	Known []string
+	// Now is the reference time; zero means time.Now.
+	Now time.Time
+}
+
+// Inspect runs every check on r.
+func Inspect(r Release, opts Options) []Finding {
+	var findings []Finding
+	add := func(kind Kind, format string, args ...any) {
+		findings = append(findings, Finding{Kind: kind, Message: fmt.Sprintf(format, args...)})
+	}
+
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	if age := now.Sub(r.Published); opts.MinAge > 0 && !r.Published.IsZero() && age < opts.MinAge {
+		add(KindRecent, "%s was published %s ago", r.Version, humanAge(age))
+	}
+
+	if r.PreviousPublishers != nil {
+		var added []string
+		for _, p := range r.Publishers {
+			if !slices.Contains(r.PreviousPublishers, p) && !slices.Contains(added, p) {
+				added = append(added, p)
+			}
+		}
+		if len(added) > 0 {
+			add(KindMaintainers, "maintainers changed since %s: %s added", r.PreviousVersion, strings.Join(added, ", "))
+		}
+	}
+
+	if r.Repository == "" {
+		add(KindRepository, "no source repository linked")
+	}
+	if len(r.InstallScripts) > 0 {
+		add(KindInstallScript, "runs %s scripts on install", strings.Join(r.InstallScripts, ", "))
+	}
+	if r.Deprecated != "" {
+		add(KindDeprecated, "deprecated: %s", r.Deprecated)
+	}
+
+	known := opts.Known
+	if known == nil {
+		known = KnownPackages
+	}
+	if original, ok := Resembles(r.Name, known); ok {
+		add(KindTyposquat, "name resembles %s", original)
+	}
+	return findings
+}
+
+// Resembles returns the well-known package name looks like without being
+// it: one edit or swap of adjacent characters away, or the same package name
+// under another npm scope (or none). Two edits would also match siblings such
+// as mcp-server-gitea and mcp-server-git. Short names such as "mcp" are too common for the scope
+// rule. Names are compared case-insensitively, with '_' and '.' read as '-'
+// as PyPI does.
+func Resembles(name string, known []string) (string, bool) {
+	n := normalize(name)
+	if slices.ContainsFunc(known, func(k string) bool { return normalize(k) == n }) {
+		return "", false
+	}
+	for _, k := range known {
+		kn := normalize(k)
+		if d := distance(n, kn); d == 1 {
+			return k, true
+		}
+		if u := unscoped(kn); u == unscoped(n) && len(u) >= 8 {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+func normalize(name string) string {
+	return strings.NewReplacer("_", "-", ".", "-").Replace(strings.ToLower(name))
+}
+
+func unscoped(name string) string {
+	if _, rest, ok := strings.Cut(name, "/"); ok && strings.HasPrefix(name, "@") {
+		return rest
+	}
+	return name
+}
+
+// distance is the edit distance between a and b, counting a swap of two
+// adjacent characters, a common typo, as one edit.
+func distance(a, b string) int {
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(b)]
+}
+
+func humanAge(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return "less than an hour"
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%d hours", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%d days", int(d.Hours()/24))
+	}
+}
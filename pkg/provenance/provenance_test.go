@@ -0,0 +1,87 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package provenance
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInspect(t *testing.T) {
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+
+	clean := Release{
+		Registry: "npm", Name: "@upstash/context7-mcp", Version: "1.0.14",
+		Published:          now.AddDate(0, -1, 0),
+		Publishers:         []string{"alice"},
+		PreviousVersion:    "1.0.13",
+		PreviousPublishers: []string{"alice", "bob"},
+		Repository:         "https://github.com/upstash/context7",
+	}
+	if got := Inspect(clean, Options{MinAge: 7 * 24 * time.Hour, Now: now}); len(got) != 0 {
+		t.Errorf("Inspect(clean) = %+v, want none", got)
+	}
+
+	risky := Release{
+		Registry: "npm", Name: "@upstash/context7-mpc", Version: "1.0.15",
+		Published:          now.Add(-3 * time.Hour),
+		Publishers:         []string{"alice", "mallory"},
+		PreviousVersion:    "1.0.14",
+		PreviousPublishers: []string{"alice"},
+		InstallScripts:     []string{"postinstall"},
+		Deprecated:         "use context7-mcp",
+	}
+	got := Inspect(risky, Options{MinAge: 7 * 24 * time.Hour, Now: now})
+	want := []Kind{KindRecent, KindMaintainers, KindRepository, KindInstallScript, KindDeprecated, KindTyposquat}
+	if len(got) != len(want) {
+		t.Fatalf("Inspect(risky) = %+v, want kinds %v", got, want)
+	}
+	for i, f := range got {
+		if f.Kind != want[i] {
+			t.Errorf("finding %d kind = %s, want %s", i, f.Kind, want[i])
+		}
+	}
+	if got[0].Message != "1.0.15 was published 3 hours ago" {
+		t.Errorf("recent message = %q", got[0].Message)
+	}
+	if got[1].Message != "maintainers changed since 1.0.14: mallory added" {
+		t.Errorf("maintainers message = %q", got[1].Message)
+	}
+
+	// Unknown metadata skips its checks.
+	if got := Inspect(Release{Name: "mcp", Repository: "x"}, Options{MinAge: time.Hour, Now: now}); len(got) != 0 {
+		t.Errorf("Inspect(no metadata) = %+v, want none", got)
+	}
+}
+
+func TestResembles(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"@modelcontextprotocol/server-github", ""},
+		{"@modelcontextprotocol/server-githuh", "@modelcontextprotocol/server-github"},
+		{"@modelcontextprotcol/server-github", "@modelcontextprotocol/server-github"},
+		{"server-github", "@modelcontextprotocol/server-github"},
+		{"@evil/server-filesystem", "@modelcontextprotocol/server-filesystem"},
+		{"mcp_server_fetch", ""},
+		{"mcp-server-fetsh", "mcp-server-fetch"},
+		{"mcp-server-fecth", "mcp-server-fetch"},
+		{"mcp", ""},
+		{"@acme/mcp", ""},
+		{"totally-different-mcp", ""},
+		// Siblings two edits apart are different packages, not typos.
+		{"mcp-server-gitea", ""},
+		{"mcp-server-timer", "mcp-server-time"},
+		{"@modelcontextprotocol/server-gitea", ""},
+		{"@modelcontextprotocol/server-gitlabs", "@modelcontextprotocol/server-gitlab"},
+		{"@modelcontextprotocol/server-slackbot", ""},
+	}
+	for _, tt := range tests {
+		got, ok := Resembles(tt.name, KnownPackages)
+		if got != tt.want || ok != (tt.want != "") {
+			t.Errorf("Resembles(%q) = %q, %v; want %q", tt.name, got, ok, tt.want)
+		}
+	}
+}
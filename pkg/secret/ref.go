@@ -0,0 +1,138 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package secret implements secret references in MCP server configuration
+// and the local encrypted store behind ${keyring:NAME} references.
+//
+// A reference has the form ${kind:name} and may appear anywhere in a value,
+// for example "Bearer ${keyring:github}":
+//
+//   - ${env:NAME}     the environment variable NAME
+//   - ${file:PATH}    the content of a file, without the trailing newline
+//   - ${keyring:NAME} a secret from the local encrypted store
+package secret
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Reference kinds.
+const (
+	KindEnv     = "env"
+	KindFile    = "file"
+	KindKeyring = "keyring"
+)
+
+// Ref is a parsed ${kind:name} reference.
+type Ref struct {
+	Kind string
+	Name string
+}
+
+func (r Ref) String() string {
+	return "${" + r.Kind + ":" + r.Name + "}"
+}
+
+var refPattern = regexp.MustCompile(`\$\{(env|file|keyring):([^}]+)\}`)
+
+// FindRefs returns the references contained in s, in order of appearance.
+func FindRefs(s string) []Ref {
+	var refs []Ref
+	for _, m := range refPattern.FindAllStringSubmatch(s, -1) {
+		refs = append(refs, Ref{Kind: m[1], Name: m[2]})
+	}
+	return refs
+}
+
+// NeedsResolver reports whether s holds references that Claude Code cannot
+// expand on its own (anything but ${env:NAME}).
+func NeedsResolver(s string) bool {
+	for _, ref := range FindRefs(s) {
+		if ref.Kind != KindEnv {
+			return true
+		}
+	}
+	return false
+}
+
+// ToClaude rewrites ${env:NAME} as ${NAME}, the syntax Claude Code expands
+// itself when it starts a server. Other references are left unchanged.
+func ToClaude(s string) string {
+	return refPattern.ReplaceAllStringFunc(s, func(match string) string {
+		m := refPattern.FindStringSubmatch(match)
+		if m[1] != KindEnv {
+			return match
+		}
+		return "${" + m[2] + "}"
+	})
+}
+
+// Expand replaces every reference in s with the value returned by resolve.
+func Expand(s string, resolve func(Ref) (string, error)) (string, error) {
+	var firstErr error
+	expanded := refPattern.ReplaceAllStringFunc(s, func(match string) string {
+		m := refPattern.FindStringSubmatch(match)
+		value, err := resolve(Ref{Kind: m[1], Name: m[2]})
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		return value
+	})
+	if firstErr != nil {
+		return "", firstErr
+	}
+	return expanded, nil
+}
+
+// Resolver resolves references against the environment, the file system and
+// a Store.
+type Resolver struct {
+	Store *Store
+}
+
+// Resolve returns the value a reference points to.
+func (r Resolver) Resolve(ref Ref) (string, error) {
+	switch ref.Kind {
+	case KindEnv:
+		value, ok := os.LookupEnv(ref.Name)
+		if !ok {
+			return "", fmt.Errorf("%s: environment variable %s is not set", ref, ref.Name)
+		}
+		return value, nil
+	case KindFile:
+		// #nosec G304 -- the path is chosen by the user who wrote the reference
+		data, err := os.ReadFile(expandHome(ref.Name))
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", ref, err)
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	case KindKeyring:
+		if r.Store == nil {
+			return "", fmt.Errorf("%s: no secret store", ref)
+		}
+		value, err := r.Store.Get(ref.Name)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", ref, err)
+		}
+		return value, nil
+	default:
+		return "", fmt.Errorf("unknown secret reference %s", ref)
+	}
+}
+
+// expandHome expands a leading ~/ to the user's home directory.
+func expandHome(path string) string {
+	rest, ok := strings.CutPrefix(path, "~/")
+	if !ok {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, rest)
+}
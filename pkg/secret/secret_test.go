@@ -0,0 +1,135 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package secret
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestFindRefs(t *testing.T) {
+	got := FindRefs("Bearer ${keyring:github} ${env:USER} ${other} ${file:~/t}")
+	want := []Ref{{KindKeyring, "github"}, {KindEnv, "USER"}, {KindFile, "~/t"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindRefs() = %v, want %v", got, want)
+	}
+}
+
+func TestToClaude(t *testing.T) {
+	tests := map[string]string{
+		"${env:GITHUB_TOKEN}":         "${GITHUB_TOKEN}",
+		"Bearer ${env:TOKEN}":         "Bearer ${TOKEN}",
+		"${keyring:github}":           "${keyring:github}",
+		"plain":                       "plain",
+		"${env:A}:${file:/run/s/key}": "${A}:${file:/run/s/key}",
+	}
+	for in, want := range tests {
+		if got := ToClaude(in); got != want {
+			t.Errorf("ToClaude(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	if NeedsResolver("${env:A}") || !NeedsResolver("x ${keyring:a}") {
+		t.Error("NeedsResolver() misclassified references")
+	}
+}
+
+func TestResolver(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir)
+	if err := store.Set("github", "ghp_secret"); err != nil {
+		t.Fatal(err)
+	}
+	tokenFile := filepath.Join(dir, "token")
+	if err := os.WriteFile(tokenFile, []byte("from-file\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("MCP_TEST_USER", "alice")
+
+	resolver := Resolver{Store: store}
+	got, err := Expand("${env:MCP_TEST_USER}:${keyring:github}:${file:"+tokenFile+"}", resolver.Resolve)
+	if err != nil {
+		t.Fatalf("Expand() error = %v", err)
+	}
+	if want := "alice:ghp_secret:from-file"; got != want {
+		t.Errorf("Expand() = %q, want %q", got, want)
+	}
+
+	if _, err := Expand("${keyring:missing}", resolver.Resolve); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expand() error = %v, want ErrNotFound", err)
+	}
+	if _, err := Expand("${env:MCP_TEST_UNSET_VARIABLE}", resolver.Resolve); err == nil {
+		t.Error("expected error for unset variable")
+	}
+}
+
+func TestStore(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir)
+
+	if names, err := store.Names(); err != nil || len(names) != 0 {
+		t.Fatalf("empty store Names() = %v, %v", names, err)
+	}
+	if err := store.Set("b", "2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Set("a", "1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// A fresh instance decrypts with the persisted key.
+	reopened := NewStore(dir)
+	if v, err := reopened.Get("b"); err != nil || v != "2" {
+		t.Errorf("Get(b) = %q, %v", v, err)
+	}
+	if names, _ := reopened.Names(); !reflect.DeepEqual(names, []string{"a", "b"}) {
+		t.Errorf("Names() = %v", names)
+	}
+
+	// The value is not stored in plain text.
+	data, _ := os.ReadFile(filepath.Join(dir, dataFile))
+	if len(data) == 0 || reflect.DeepEqual(data, []byte(`{"a":"1","b":"2"}`)) {
+		t.Errorf("secrets file is not encrypted: %q", data)
+	}
+	if info, err := os.Stat(filepath.Join(dir, keyFile)); err != nil || info.Mode().Perm() != filePerm {
+		t.Errorf("key file mode = %v, %v", info.Mode(), err)
+	}
+
+	if err := reopened.Delete("a"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get("a"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(a) after Delete error = %v", err)
+	}
+	if err := store.Delete("a"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Delete(a) twice error = %v", err)
+	}
+}
+
+func TestStore_ConcurrentSet(t *testing.T) {
+	dir := t.TempDir()
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Separate instances, like separate `secret set` runs.
+			if err := NewStore(dir).Set(fmt.Sprintf("s%d", i), "v"); err != nil {
+				t.Errorf("Set(s%d) error = %v", i, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	names, err := NewStore(dir).Names()
+	if err != nil || len(names) != 10 {
+		t.Errorf("Names() = %v, %v; want all 10 secrets", names, err)
+	}
+}
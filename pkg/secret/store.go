@@ -0,0 +1,185 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package secret
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/gizzahub/gzh-cli-mcp-plugin/internal/fsutil"
+)
+
+const (
+	keyFile  = "secret.key"
+	dataFile = "secrets.enc"
+	keySize  = 32 // AES-256
+	filePerm = 0o600
+)
+
+// ErrNotFound is returned when a secret does not exist in the store.
+var ErrNotFound = errors.New("secret not found")
+
+// Store keeps named secrets in a file encrypted with AES-256-GCM. The key is
+// a separate owner-only file in the same directory, created on first use.
+// This keeps credentials out of Claude Code's config files, their backups
+// and exports; it does not protect against someone who can read the key.
+type Store struct {
+	dir string
+}
+
+// NewStore returns a store rooted at dir.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Dir returns the directory holding the key and the encrypted secrets.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Get returns the secret stored under name.
+func (s *Store) Get(name string) (string, error) {
+	secrets, err := s.load()
+	if err != nil {
+		return "", err
+	}
+	value, ok := secrets[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	return value, nil
+}
+
+// Set stores value under name, replacing any previous value.
+func (s *Store) Set(name, value string) error {
+	return s.modify(func(secrets map[string]string) error {
+		secrets[name] = value
+		return nil
+	})
+}
+
+// Delete removes the secret stored under name.
+func (s *Store) Delete(name string) error {
+	return s.modify(func(secrets map[string]string) error {
+		if _, ok := secrets[name]; !ok {
+			return fmt.Errorf("%w: %s", ErrNotFound, name)
+		}
+		delete(secrets, name)
+		return nil
+	})
+}
+
+// Names returns the names of all stored secrets, sorted.
+func (s *Store) Names() ([]string, error) {
+	secrets, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	return slices.Sorted(maps.Keys(secrets)), nil
+}
+
+func (s *Store) load() (map[string]string, error) {
+	// #nosec G304 -- path is under the mcp-plugin state directory
+	data, err := os.ReadFile(filepath.Join(s.dir, dataFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return make(map[string]string), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secrets: %w", err)
+	}
+	return s.decrypt(data)
+}
+
+// modify runs a read-modify-write of the secrets under a lock, so concurrent
+// runs do not lose each other's changes, nor create two keys.
+func (s *Store) modify(change func(secrets map[string]string) error) error {
+	return fsutil.Update(filepath.Join(s.dir, dataFile), filePerm, func(current []byte) ([]byte, error) {
+		secrets := make(map[string]string)
+		if current != nil {
+			var err error
+			if secrets, err = s.decrypt(current); err != nil {
+				return nil, err
+			}
+		}
+		if err := change(secrets); err != nil {
+			return nil, err
+		}
+		return s.encrypt(secrets)
+	})
+}
+
+func (s *Store) decrypt(data []byte) (map[string]string, error) {
+	gcm, err := s.cipher(false)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("secrets file is corrupt")
+	}
+	nonce, sealed := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt secrets (wrong key?): %w", err)
+	}
+
+	secrets := make(map[string]string)
+	if err := json.Unmarshal(plain, &secrets); err != nil {
+		return nil, fmt.Errorf("failed to parse secrets: %w", err)
+	}
+	return secrets, nil
+}
+
+func (s *Store) encrypt(secrets map[string]string) ([]byte, error) {
+	plain, err := json.Marshal(secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := s.cipher(true)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plain, nil), nil
+}
+
+// cipher loads the store key, generating it when create is set.
+func (s *Store) cipher(create bool) (cipher.AEAD, error) {
+	path := filepath.Join(s.dir, keyFile)
+
+	// #nosec G304 -- path is under the mcp-plugin state directory
+	key, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) && create {
+		key = make([]byte, keySize)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+		if err := fsutil.WriteFile(path, key, filePerm); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read secret key: %w", err)
+	}
+	if len(key) != keySize {
+		return nil, fmt.Errorf("secret key %s has invalid length", path)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package versions picks package versions the way npm resolves specs: by
+// exact version, dist-tag or semver range, and by upgrade policy.
+package versions
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// Policy bounds how far an update may move from the current version.
+type Policy string
+
+// Update policies.
+const (
+	// Patch allows x.y.Z: same major and minor.
+	Patch Policy = "patch"
+	// Minor allows x.Y.Z: same major.
+	Minor Policy = "minor"
+	// Major follows the latest dist-tag, across major versions.
+	Major Policy = "major"
+)
+
+// Policies lists the policies in increasing reach.
+var Policies = []Policy{Patch, Minor, Major}
+
+// ParsePolicy validates a policy name.
+func ParsePolicy(s string) (Policy, error) {
+	if p := Policy(s); slices.Contains(Policies, p) {
+		return p, nil
+	}
+	return "", fmt.Errorf("unknown update policy %q (valid: patch, minor, major)", s)
+}
+
+// IsExact reports whether spec names one version (1.2.3, 1.2.3-beta.1), as
+// opposed to a tag or a range such as 1.2 or ^1.2.0.
+func IsExact(spec string) bool {
+	_, err := semver.StrictNewVersion(spec)
+	return err == nil
+}
+
+// Resolve returns the version spec selects from available: the latest tag
+// when spec is empty, then a dist-tag, an exact version, or the highest
+// version in a semver range ("^1.2.0", "~1.2", "1.x", ">=1 <3").
+// Pre-releases only match ranges that name one, as in npm.
+func Resolve(spec string, available []string, tags map[string]string) (string, error) {
+	if spec == "" {
+		spec = "latest"
+	}
+	if v, ok := tags[spec]; ok && v != "" {
+		return v, nil
+	}
+	if slices.Contains(available, spec) {
+		return spec, nil
+	}
+
+	constraint, err := semver.NewConstraint(spec)
+	if err != nil {
+		return "", fmt.Errorf("%q is not a version, tag or range", spec)
+	}
+	var best *semver.Version
+	var bestRaw string
+	for _, raw := range available {
+		v, err := semver.NewVersion(raw)
+		if err != nil || !constraint.Check(v) {
+			continue
+		}
+		if best == nil || v.GreaterThan(best) {
+			best, bestRaw = v, raw
+		}
+	}
+	if best == nil {
+		return "", fmt.Errorf("no version matches %q", spec)
+	}
+	return bestRaw, nil
+}
+
+// Upgrade returns the newest version policy allows, starting from the
+// version current resolves to. It never returns an older version than that,
+// and only moves to a pre-release when already on one.
+func Upgrade(current string, policy Policy, available []string, tags map[string]string) (string, error) {
+	base, err := Resolve(current, available, tags)
+	if err != nil {
+		return "", err
+	}
+	baseVersion, err := semver.NewVersion(base)
+	if err != nil {
+		// Not semver: only the latest tag can be followed.
+		if latest := tags["latest"]; policy == Major && latest != "" {
+			return latest, nil
+		}
+		return base, nil
+	}
+
+	if policy == Major {
+		latest := tags["latest"]
+		if v, err := semver.NewVersion(latest); err == nil && !v.LessThan(baseVersion) {
+			return latest, nil
+		}
+		return base, nil
+	}
+
+	best, bestRaw := baseVersion, base
+	for _, raw := range available {
+		v, err := semver.NewVersion(raw)
+		if err != nil || !v.GreaterThan(best) {
+			continue
+		}
+		if v.Prerelease() != "" && baseVersion.Prerelease() == "" {
+			continue
+		}
+		if v.Major() != baseVersion.Major() || (policy == Patch && v.Minor() != baseVersion.Minor()) {
+			continue
+		}
+		best, bestRaw = v, raw
+	}
+	return bestRaw, nil
+}
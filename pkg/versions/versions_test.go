@@ -0,0 +1,89 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package versions
+
+import "testing"
+
+var (
+	available = []string{"0.9.0", "1.0.0", "1.0.1", "1.1.0", "1.2.0", "1.2.1", "1.3.0-beta.1", "2.0.0", "2.1.0", "3.0.0-rc.1"}
+	tags      = map[string]string{"latest": "2.1.0", "next": "3.0.0-rc.1"}
+)
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    string
+		wantErr bool
+	}{
+		{"", "2.1.0", false},
+		{"latest", "2.1.0", false},
+		{"next", "3.0.0-rc.1", false},
+		{"1.0.1", "1.0.1", false},
+		{"^1.0.0", "1.2.1", false},
+		{"~1.0.0", "1.0.1", false},
+		{"1.x", "1.2.1", false},
+		{"1.2", "1.2.1", false},
+		{">=1.1.0 <2.0.0", "1.2.1", false},
+		{"^0.9.0 || ^2.0.0", "2.1.0", false},
+		{"^1.3.0-beta.0", "1.3.0-beta.1", false},
+		{"1.0.2", "", true},
+		{"^4.0.0", "", true},
+		{"beta", "", true},
+	}
+	for _, tt := range tests {
+		got, err := Resolve(tt.spec, available, tags)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("Resolve(%q) = %q, %v; want %q (error %v)", tt.spec, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestUpgrade(t *testing.T) {
+	tests := []struct {
+		current string
+		policy  Policy
+		want    string
+	}{
+		{"1.0.0", Patch, "1.0.1"},
+		{"1.0.0", Minor, "1.2.1"},
+		{"1.0.0", Major, "2.1.0"},
+		{"1.2.1", Patch, "1.2.1"},
+		{"2.1.0", Minor, "2.1.0"},
+		// Ranges start from what they resolve to.
+		{"^1.0.0", Patch, "1.2.1"},
+		{"", Minor, "2.1.0"},
+		// Never downgrade below the current version.
+		{"3.0.0-rc.1", Major, "3.0.0-rc.1"},
+		{"1.3.0-beta.1", Patch, "1.3.0-beta.1"},
+	}
+	for _, tt := range tests {
+		got, err := Upgrade(tt.current, tt.policy, available, tags)
+		if err != nil || got != tt.want {
+			t.Errorf("Upgrade(%q, %s) = %q, %v; want %q", tt.current, tt.policy, got, err, tt.want)
+		}
+	}
+
+	// PyPI-style versions outside semver can still follow latest.
+	got, err := Upgrade("2024.1", Major, []string{"2024.1", "2024.10"}, map[string]string{"latest": "2024.10"})
+	if err != nil || got != "2024.10" {
+		t.Errorf("Upgrade(calver) = %q, %v; want 2024.10", got, err)
+	}
+}
+
+func TestParsePolicy(t *testing.T) {
+	if p, err := ParsePolicy("minor"); err != nil || p != Minor {
+		t.Errorf("ParsePolicy(minor) = %q, %v", p, err)
+	}
+	if _, err := ParsePolicy("latest"); err == nil {
+		t.Error("ParsePolicy(latest) error = nil, want unknown policy")
+	}
+}
+
+func TestIsExact(t *testing.T) {
+	for spec, want := range map[string]bool{"1.2.3": true, "1.2.3-beta.1": true, "1.2": false, "^1.2.3": false, "latest": false, "": false} {
+		if got := IsExact(spec); got != want {
+			t.Errorf("IsExact(%q) = %v, want %v", spec, got, want)
+		}
+	}
+}